@@ -1,9 +1,14 @@
 package discord
 
 import (
-	"fmt"
+	"encoding/json"
+	"io"
+	"log"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/andersfylling/disgord"
@@ -13,24 +18,96 @@ type Config struct {
 	Token    string
 	ClientID string
 	Secret   string
+	// Transport sends the HTTP requests to Discord, http.DefaultTransport is used when nil.
+	// Tests use it to serve canned responses instead of calling the Discord API.
+	Transport http.RoundTripper
+	// Debug logs every request to Discord along with the rate limit headers of the response.
+	Debug bool
+	// DryRun logs the requests which would change something on Discord instead of sending them. Reads are still sent.
+	DryRun bool
 }
 
 type Context struct {
-	Client *disgord.Client
-	Config *Config
+	Client     *disgord.Client
+	HTTPClient *http.Client
+	Config     *Config
+
+	// voiceRegions caches the voice regions, which don't change during a run.
+	voiceRegions      []*voiceRegion
+	voiceRegionsMutex sync.Mutex
 }
 
 // This type implements the http.RoundTripper interface
 type LimitedRoundTripper struct {
 	Proxied http.RoundTripper
+	Debug   bool
+	DryRun  bool
+}
+
+// Webhook and interaction tokens are part of the path, they must not end up in the logs.
+var tokenPathSegment = regexp.MustCompile(`(/(?:webhooks|interactions)/\d+/)[^/?]+`)
+
+func redactDiscordPath(path string) string {
+	return tokenPathSegment.ReplaceAllString(path, "${1}<redacted>")
+}
+
+// logRequest writes a request to the Terraform logs, shown with TF_LOG=DEBUG. Headers aren't logged apart from
+// the rate limit ones, so the bot token never shows up.
+func logRequest(req *http.Request, res *http.Response, e error) {
+	path := redactDiscordPath(req.URL.Path)
+	if e != nil {
+		log.Printf("[DEBUG] Discord API %s %s failed: %s", req.Method, path, redactDiscordPath(e.Error()))
+		return
+	}
+
+	log.Printf("[DEBUG] Discord API %s %s: %d (remaining: %q, reset after: %q, bucket: %q, global: %q, scope: %q)",
+		req.Method, path, res.StatusCode,
+		res.Header.Get("X-RateLimit-Remaining"), res.Header.Get("X-RateLimit-Reset-After"), res.Header.Get("X-RateLimit-Bucket"),
+		res.Header.Get("X-RateLimit-Global"), res.Header.Get("X-RateLimit-Scope"))
+}
+
+// dryRunResponse logs a request changing something on Discord instead of sending it. The response succeeds and
+// echoes the payload, so the resource carries on and every request it would have sent ends up in the logs.
+func dryRunResponse(req *http.Request) (*http.Response, error) {
+	payload := ""
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		payload = string(b)
+	}
+
+	path := redactDiscordPath(req.URL.Path)
+	log.Printf("[WARN] Dry run, not sending Discord API %s %s %s", req.Method, path, payload)
+
+	// Discord answers deletions and requests without a payload with no content, anything else returns the object.
+	status, body := http.StatusOK, payload
+	if req.Method == http.MethodDelete || payload == "" {
+		status, body = http.StatusNoContent, ""
+	} else if !json.Valid([]byte(payload)) {
+		body = "{}"
+	}
+
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
 }
 
 func (lrt LimitedRoundTripper) RoundTrip(req *http.Request) (res *http.Response, e error) {
-	// Do "before sending requests" actions here.
-	fmt.Printf("Sending request to %v\n", req.URL)
+	if lrt.DryRun && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return dryRunResponse(req)
+	}
 
 	// Send the request, get the response (or the error)
 	res, e = lrt.Proxied.RoundTrip(req)
+	if lrt.Debug {
+		logRequest(req, res, e)
+	}
 
 	if res != nil && res.StatusCode == 429 {
 		retryAfter := res.Header.Get("X-RateLimit-Reset-After")
@@ -48,11 +125,16 @@ func (lrt LimitedRoundTripper) RoundTrip(req *http.Request) (res *http.Response,
 }
 
 func (c *Config) Client() (*Context, error) {
-	httpClient := &http.Client{Transport: LimitedRoundTripper{http.DefaultTransport}}
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	httpClient := &http.Client{Transport: LimitedRoundTripper{Proxied: transport, Debug: c.Debug, DryRun: c.DryRun}}
 	client := disgord.New(disgord.Config{
 		BotToken:   c.Token,
 		HTTPClient: httpClient,
 	})
 
-	return &Context{Client: client, Config: c}, nil
+	return &Context{Client: client, HTTPClient: httpClient, Config: c}, nil
 }
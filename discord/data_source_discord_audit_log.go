@@ -0,0 +1,178 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type auditLogChange struct {
+	Key      string          `json:"key"`
+	OldValue json.RawMessage `json:"old_value,omitempty"`
+	NewValue json.RawMessage `json:"new_value,omitempty"`
+}
+
+type auditLogEntry struct {
+	ID         string            `json:"id"`
+	ActionType int               `json:"action_type"`
+	TargetID   string            `json:"target_id"`
+	UserID     string            `json:"user_id"`
+	Reason     string            `json:"reason"`
+	Changes    []*auditLogChange `json:"changes"`
+}
+
+type auditLog struct {
+	AuditLogEntries []*auditLogEntry `json:"audit_log_entries"`
+}
+
+func dataSourceDiscordAuditLog() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDiscordAuditLogRead,
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"action_type": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  50,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					v := val.(int)
+					if v < 1 || v > 1000 {
+						errors = append(errors, fmt.Errorf("limit must be between 1 and 1000 inclusive, got: %d", v))
+					}
+
+					return
+				},
+			},
+			"entries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action_type": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"target_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"changes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"old_value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"new_value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDiscordAuditLogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := getId(d.Get("server_id").(string))
+	limit := d.Get("limit").(int)
+
+	query := url.Values{}
+	if v, ok := d.GetOk("action_type"); ok {
+		query.Set("action_type", strconv.Itoa(v.(int)))
+	}
+	if v, ok := d.GetOk("user_id"); ok {
+		query.Set("user_id", v.(string))
+	}
+
+	// The audit log is returned newest first and at most 100 entries at a time.
+	entries := make([]*auditLogEntry, 0, limit)
+	for len(entries) < limit {
+		pageSize := limit - len(entries)
+		if pageSize > 100 {
+			pageSize = 100
+		}
+		query.Set("limit", strconv.Itoa(pageSize))
+		if len(entries) > 0 {
+			query.Set("before", entries[len(entries)-1].ID)
+		}
+
+		var page auditLog
+		path := fmt.Sprintf("/guilds/%s/audit-logs?%s", serverId.String(), query.Encode())
+		if err := discordRequest(ctx, m, http.MethodGet, path, nil, &page); err != nil {
+			return diag.Errorf("Failed to fetch audit log of server %s: %s", serverId.String(), err.Error())
+		}
+
+		entries = append(entries, page.AuditLogEntries...)
+		if len(page.AuditLogEntries) < pageSize {
+			break
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(entries))
+	for _, e := range entries {
+		changes := make([]map[string]interface{}, 0, len(e.Changes))
+		for _, c := range e.Changes {
+			changes = append(changes, map[string]interface{}{
+				"key":       c.Key,
+				"old_value": string(c.OldValue),
+				"new_value": string(c.NewValue),
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":          e.ID,
+			"action_type": e.ActionType,
+			"target_id":   e.TargetID,
+			"user_id":     e.UserID,
+			"reason":      e.Reason,
+			"changes":     changes,
+		})
+	}
+
+	d.SetId(serverId.String())
+	d.Set("entries", result)
+
+	return diags
+}
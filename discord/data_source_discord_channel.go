@@ -0,0 +1,143 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+func dataSourceDiscordChannel() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDiscordChannelRead,
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "channel_id"},
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Type of the channel to look for, any type when not set.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					v := val.(string)
+					if _, ok := getDiscordChannelType(v); !ok {
+						errors = append(errors, fmt.Errorf("%s is not a valid channel type", v))
+					}
+
+					return
+				},
+			},
+			// Threads aren't listed with the channels of the server, so they can only be looked up by their ID.
+			"channel_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "channel_id"},
+			},
+			"position": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"category": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"topic": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"nsfw": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bitrate": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"user_limit": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_message_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"message_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of messages in a thread, Discord reports none for other channels.",
+			},
+			"member_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of members of a thread, Discord stops counting at 50 and reports none for other channels.",
+			},
+		},
+	}
+}
+
+func dataSourceDiscordChannelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	serverId := getId(d.Get("server_id").(string))
+	var channel *disgord.Channel
+	if v, ok := d.GetOk("channel_id"); ok {
+		var err error
+		channel, err = client.Channel(getId(v.(string))).Get()
+		if err != nil {
+			return diag.Errorf("Failed to fetch channel %s: %s", v.(string), err.Error())
+		}
+		if channel.GuildID != serverId {
+			return diag.Errorf("Channel %s is not a channel of server %s", v.(string), serverId.String())
+		}
+	} else {
+		channels, err := client.Guild(serverId).GetChannels()
+		if err != nil {
+			return diag.Errorf("Failed to fetch channels of server %s: %s", serverId.String(), err.Error())
+		}
+
+		channel, err = findChannelByName(channels, d.Get("name").(string), d.Get("type").(string))
+		if err != nil {
+			return diag.Errorf("Failed to find channel in server %s: %s", serverId.String(), err.Error())
+		}
+	}
+	channelType, _ := getTextChannelType(channel.Type)
+	if isThreadChannel(channel.Type) {
+		channelType = "thread"
+	}
+
+	d.SetId(channel.ID.String())
+	d.Set("channel_id", channel.ID.String())
+	d.Set("name", channel.Name)
+	d.Set("type", channelType)
+	d.Set("position", channel.Position)
+	d.Set("topic", channel.Topic)
+	d.Set("nsfw", channel.NSFW)
+	d.Set("bitrate", channel.Bitrate)
+	d.Set("user_limit", channel.UserLimit)
+	if channel.ParentID.IsZero() {
+		d.Set("category", "")
+	} else {
+		d.Set("category", channel.ParentID.String())
+	}
+	if channel.LastMessageID.IsZero() {
+		d.Set("last_message_id", "")
+	} else {
+		d.Set("last_message_id", channel.LastMessageID.String())
+	}
+	d.Set("message_count", channel.MessageCount)
+	d.Set("member_count", channel.MemberCount)
+
+	return diags
+}
@@ -0,0 +1,100 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceChannelByName(t *testing.T) {
+	channels := `[
+		{"id": "10", "type": 4, "name": "general", "position": 0},
+		{"id": "11", "type": 0, "name": "general", "position": 1, "parent_id": "10", "topic": "hello"},
+		{"id": "12", "type": 2, "name": "general", "position": 2, "parent_id": "10", "bitrate": 96000},
+		{"id": "13", "type": 0, "name": "rules", "position": 3}
+	]`
+
+	params := []struct {
+		name        string
+		channelType string
+		id          string
+		err         string
+	}{
+		{name: "rules", id: "13"},
+		{name: "general", channelType: "text", id: "11"},
+		{name: "general", channelType: "voice", id: "12"},
+		{name: "general", err: "11 (text)"},
+		{name: "general", channelType: "news", err: "no news channel"},
+		{name: "missing", err: "no channel named"},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/channels": {{status: http.StatusOK, body: channels}},
+		})
+
+		r := dataSourceDiscordChannel()
+		config := map[string]interface{}{"server_id": "1", "name": p.name}
+		if p.channelType != "" {
+			config["type"] = p.channelType
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+		diags := dataSourceDiscordChannelRead(context.Background(), d, c)
+
+		if p.err != "" {
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, p.err) {
+				t.Errorf("name: %v, type: %v - error Error: ex: %v, ac: %v", p.name, p.channelType, p.err, diags)
+			}
+			continue
+		}
+		if diags.HasError() {
+			t.Fatalf("name: %v, type: %v - read Error: ex: %v, ac: %v", p.name, p.channelType, nil, diags)
+		}
+		if d.Id() != p.id {
+			t.Errorf("name: %v, type: %v - id Error: ex: %v, ac: %v", p.name, p.channelType, p.id, d.Id())
+		}
+	}
+}
+
+func TestDataSourceChannelThreadCounts(t *testing.T) {
+	params := []struct {
+		body         string
+		channelType  string
+		messageCount int
+		memberCount  int
+		err          bool
+	}{
+		{body: `{"id": "20", "guild_id": "1", "type": 11, "name": "thread", "parent_id": "11", "last_message_id": "30", "message_count": 12, "member_count": 3}`,
+			channelType: "thread", messageCount: 12, memberCount: 3},
+		{body: `{"id": "20", "guild_id": "1", "type": 0, "name": "general"}`, channelType: "text"},
+		{body: `{"id": "20", "guild_id": "2", "type": 0, "name": "general"}`, err: true},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /channels/20": {{status: http.StatusOK, body: p.body}},
+		})
+
+		d := schema.TestResourceDataRaw(t, dataSourceDiscordChannel().Schema, map[string]interface{}{"server_id": "1", "channel_id": "20"})
+		diags := dataSourceDiscordChannelRead(context.Background(), d, c)
+		if diags.HasError() != p.err {
+			t.Fatalf("body: %v - read Error: ex: %v, ac: %v", p.body, p.err, diags)
+		}
+		if p.err {
+			continue
+		}
+
+		if ac := d.Get("type").(string); ac != p.channelType {
+			t.Errorf("body: %v - type Error: ex: %v, ac: %v", p.body, p.channelType, ac)
+		}
+		if ac := d.Get("message_count").(int); ac != p.messageCount {
+			t.Errorf("body: %v - message_count Error: ex: %v, ac: %v", p.body, p.messageCount, ac)
+		}
+		if ac := d.Get("member_count").(int); ac != p.memberCount {
+			t.Errorf("body: %v - member_count Error: ex: %v, ac: %v", p.body, p.memberCount, ac)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package discord
 import (
 	"fmt"
 
-	"github.com/andersfylling/disgord"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"golang.org/x/net/context"
@@ -56,6 +55,20 @@ func dataSourceDiscordMember() *schema.Resource {
 				Computed: true,
 				Set:      schema.HashString,
 			},
+			"pending": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the member hasn't passed membership screening yet.",
+			},
+			"flags": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bypasses_verification": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the member may talk without meeting the verification level of the server.",
+			},
 			"in_server": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -66,20 +79,19 @@ func dataSourceDiscordMember() *schema.Resource {
 
 func dataSourceMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	var member *disgord.Member
+	var member *serverMember
 	var memberErr error
-	client := m.(*Context).Client
 	serverId := getId(d.Get("server_id").(string))
 
 	if v, ok := d.GetOk("user_id"); ok {
-		member, memberErr = client.Guild(serverId).Member(getId(v.(string))).Get()
+		member, memberErr = getServerMember(ctx, m, serverId, getId(v.(string)))
 	}
 
 	if v, ok := d.GetOk("username"); ok {
 		username := v.(string)
 		discriminator := d.Get("discriminator").(string)
 
-		members, err := client.Guild(serverId).GetMembers(&disgord.GetMembers{Limit: 0})
+		members, err := getServerMembers(ctx, m, serverId)
 		if err != nil {
 			return diag.Errorf("Failed to fetch members for %s: %s", serverId.String(), err.Error())
 		}
@@ -103,6 +115,9 @@ func dataSourceMemberRead(ctx context.Context, d *schema.ResourceData, m interfa
 		d.Set("discriminator", nil)
 		d.Set("avatar", nil)
 		d.Set("nick", nil)
+		d.Set("pending", false)
+		d.Set("flags", 0)
+		d.Set("bypasses_verification", false)
 		return diags
 	}
 
@@ -120,5 +135,9 @@ func dataSourceMemberRead(ctx context.Context, d *schema.ResourceData, m interfa
 	d.Set("avatar", member.User.Avatar)
 	d.Set("nick", member.Nick)
 
+	d.Set("pending", member.Pending)
+	d.Set("flags", member.Flags)
+	d.Set("bypasses_verification", member.Flags&memberFlagBypassesVerification != 0)
+
 	return diags
 }
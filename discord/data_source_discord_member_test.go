@@ -0,0 +1,100 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMemberPending(t *testing.T) {
+	params := []struct {
+		body    string
+		pending bool
+	}{
+		{body: `{"user": {"id": "2", "username": "a"}, "roles": [], "pending": true}`, pending: true},
+		{body: `{"user": {"id": "2", "username": "a"}, "roles": [], "pending": false}`, pending: false},
+		{body: `{"user": {"id": "2", "username": "a"}, "roles": []}`, pending: false},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/members/2": {{status: http.StatusOK, body: p.body}},
+		})
+
+		d := schema.TestResourceDataRaw(t, dataSourceDiscordMember().Schema, map[string]interface{}{
+			"server_id": "1",
+			"user_id":   "2",
+		})
+		if diags := dataSourceMemberRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("body: %v - read Error: ex: %v, ac: %v", p.body, nil, diags)
+		}
+		if ac := d.Get("pending").(bool); ac != p.pending {
+			t.Errorf("body: %v - pending Error: ex: %v, ac: %v", p.body, p.pending, ac)
+		}
+	}
+}
+
+func TestMemberFlags(t *testing.T) {
+	params := []struct {
+		body   string
+		flags  int
+		bypass bool
+	}{
+		{body: `{"user": {"id": "2", "username": "a"}, "roles": [], "flags": 4}`, flags: 4, bypass: true},
+		{body: `{"user": {"id": "2", "username": "a"}, "roles": [], "flags": 3}`, flags: 3, bypass: false},
+		{body: `{"user": {"id": "2", "username": "a"}, "roles": []}`, flags: 0, bypass: false},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/members/2": {{status: http.StatusOK, body: p.body}},
+		})
+
+		d := schema.TestResourceDataRaw(t, dataSourceDiscordMember().Schema, map[string]interface{}{
+			"server_id": "1",
+			"user_id":   "2",
+		})
+		if diags := dataSourceMemberRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("body: %v - read Error: ex: %v, ac: %v", p.body, nil, diags)
+		}
+		if ac := d.Get("flags").(int); ac != p.flags {
+			t.Errorf("body: %v - flags Error: ex: %v, ac: %v", p.body, p.flags, ac)
+		}
+		if ac := d.Get("bypasses_verification").(bool); ac != p.bypass {
+			t.Errorf("body: %v - bypasses_verification Error: ex: %v, ac: %v", p.body, p.bypass, ac)
+		}
+		if ac := transport.count("GET /guilds/1/members/2"); ac != 1 {
+			t.Errorf("body: %v - requests Error: ex: %v, ac: %v", p.body, 1, ac)
+		}
+	}
+}
+
+func TestMemberByUsername(t *testing.T) {
+	memberPageSize = 1
+	defer func() { memberPageSize = 1000 }()
+
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/members": {
+			{status: http.StatusOK, body: `[{"user": {"id": "2", "username": "a", "discriminator": "0001"}, "roles": []}]`},
+			{status: http.StatusOK, body: `[{"user": {"id": "3", "username": "b", "discriminator": "0002"}, "roles": [], "pending": true, "flags": 4}]`},
+			{status: http.StatusOK, body: `[]`},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceDiscordMember().Schema, map[string]interface{}{
+		"server_id":     "1",
+		"username":      "b",
+		"discriminator": "0002",
+	})
+	if diags := dataSourceMemberRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+	if d.Id() != "3" || !d.Get("pending").(bool) || !d.Get("bypasses_verification").(bool) {
+		t.Errorf("member Error: ex: %v, ac: %v", "member 3, pending and bypassing verification", d.State())
+	}
+	if ac := transport.count("GET /guilds/1/members"); ac != 3 {
+		t.Errorf("pagination Error: ex: %v, ac: %v", 3, ac)
+	}
+}
@@ -2,8 +2,8 @@ package discord
 
 import (
 	"context"
+	"strings"
 
-	"github.com/andersfylling/disgord"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -50,51 +50,81 @@ func dataSourceDiscordRole() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"compute_member_count": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Count the members with the role into member_count, which pages through every member of the server.",
+			},
+			"member_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"tags": roleTagsSchema(),
 		},
 	}
 }
 
 func dataSourceDiscordRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	var err error
-	var role *disgord.Role
+	var role *serverRole
 	client := m.(*Context).Client
 
 	serverId := getId(d.Get("server_id").(string))
-	server, err := client.Guild(serverId).Get()
+	roles, err := getServerRoles(ctx, m, serverId)
 	if err != nil {
-		return diag.Errorf("Failed to fetch server %s: %s", serverId.String(), err.Error())
+		return diag.Errorf("Failed to fetch roles of server %s: %s", serverId.String(), err.Error())
 	}
 
 	if v, ok := d.GetOk("role_id"); ok {
-		role, err = server.Role(getId(v.(string)))
-		if err != nil {
-			return diag.Errorf("Failed to fetch role %s: %s", v.(string), err.Error())
+		role = findServerRole(roles, getId(v.(string)))
+		if role == nil {
+			return diag.Errorf("Failed to fetch role %s: no role with that ID exists in server %s", v.(string), serverId.String())
 		}
 	}
 
 	if v, ok := d.GetOk("name"); ok {
-		roles, err := server.RoleByName(v.(string))
-		if err != nil {
-			return diag.Errorf("Failed to fetch role %s: %s", v.(string), err.Error())
+		matches := make([]*serverRole, 0, 1)
+		for _, r := range roles {
+			if r.Name == v.(string) {
+				matches = append(matches, r)
+			}
 		}
 
-		if len(roles) <= 0 {
-			return diag.Errorf("Failed to fetch role %s", v.(string))
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("Failed to fetch role %s: no role with that name exists in server %s", v.(string), serverId.String())
+		case 1:
+			role = matches[0]
+		default:
+			ids := make([]string, 0, len(matches))
+			for _, r := range matches {
+				ids = append(ids, r.ID.String())
+			}
+			return diag.Errorf("Failed to fetch role %s: %d roles have that name (%s), set role_id instead",
+				v.(string), len(matches), strings.Join(ids, ", "))
 		}
-
-		role = roles[0]
 	}
 
 	d.SetId(role.ID.String())
 	d.Set("role_id", role.ID.String())
 	d.Set("name", role.Name)
-	d.Set("position", len(server.Roles)-role.Position)
+	d.Set("position", len(roles)-role.Position)
 	d.Set("color", role.Color)
 	d.Set("hoist", role.Hoist)
 	d.Set("mentionable", role.Mentionable)
 	d.Set("permissions", role.Permissions)
 	d.Set("managed", role.Managed)
 
+	d.Set("tags", flattenRoleTags(role.Tags))
+
+	if d.Get("compute_member_count").(bool) {
+		count, err := countRoleMembers(client, serverId, role.ID)
+		if err != nil {
+			return diag.Errorf("Failed to fetch members for %s: %s", serverId.String(), err.Error())
+		}
+		d.Set("member_count", count)
+	}
+
 	return diags
 }
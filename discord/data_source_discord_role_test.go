@@ -0,0 +1,56 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceRoleByName(t *testing.T) {
+	roles := `[
+		{"id": "1", "name": "@everyone", "position": 0},
+		{"id": "5", "name": "Mods", "position": 2, "color": 255},
+		{"id": "6", "name": "Bot", "position": 1, "managed": true, "tags": {"bot_id": "7"}},
+		{"id": "8", "name": "Bot", "position": 3}
+	]`
+
+	params := []struct {
+		name string
+		id   string
+		err  string
+	}{
+		{name: "Mods", id: "5"},
+		{name: "Bot", err: "2 roles have that name (6, 8)"},
+		{name: "Admins", err: "no role with that name"},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1":       {{status: http.StatusOK, body: `{"id": "1", "name": "server", "roles": ` + roles + `}`}},
+			"GET /guilds/1/roles": {{status: http.StatusOK, body: roles}},
+		})
+
+		r := dataSourceDiscordRole()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"server_id": "1", "name": p.name})
+		diags := dataSourceDiscordRoleRead(context.Background(), d, c)
+
+		if p.err != "" {
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, p.err) {
+				t.Errorf("name: %v - error Error: ex: %v, ac: %v", p.name, p.err, diags)
+			}
+			continue
+		}
+		if diags.HasError() {
+			t.Fatalf("name: %v - read Error: ex: %v, ac: %v", p.name, nil, diags)
+		}
+		if d.Id() != p.id {
+			t.Errorf("name: %v - id Error: ex: %v, ac: %v", p.name, p.id, d.Id())
+		}
+		if ac := d.Get("color").(int); ac != 255 {
+			t.Errorf("name: %v - color Error: ex: %v, ac: %v", p.name, 255, ac)
+		}
+	}
+}
@@ -27,8 +27,9 @@ func dataSourceDiscordServer() *schema.Resource {
 				Computed: true,
 			},
 			"default_message_notifications": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Server-wide default notification setting for members: 0 notifies on all messages, 1 notifies only on mentions.",
 			},
 			"verification_level": {
 				Type:     schema.TypeInt,
@@ -58,6 +59,67 @@ func dataSourceDiscordServer() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"nsfw_level": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bot_is_owner": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bot_permissions": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Server-wide permission bits of the bot, from @everyone and the bot's roles.",
+			},
+			"bot_permission_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"max_members": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"max_presences": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"emoji_limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Emoji slots of the server's boost tier, for static and animated emojis each.",
+			},
+			"emoji_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"animated_emoji_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"sticker_limit": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"sticker_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vanity_url_code": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Vanity invite code of the server, only set for servers with the VANITY_URL feature.",
+			},
+			// Discord doesn't accept hub_type in the modify guild payload, it's only reported for Student Hubs.
+			"hub_type": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -95,7 +157,7 @@ func dataSourceDiscordServerRead(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(server.ID.String())
 	d.Set("server_id", server.ID.String())
 	d.Set("name", server.Name)
-	d.Set("region", server.Region)
+	d.Set("region", normalizeServerRegion(server.Region))
 	d.Set("afk_timeout", server.AfkTimeout)
 	d.Set("icon_hash", server.Icon)
 	d.Set("splash_hash", server.Splash)
@@ -110,5 +172,75 @@ func dataSourceDiscordServerRead(ctx context.Context, d *schema.ResourceData, m
 		d.Set("owner_id", server.OwnerID.String())
 	}
 
+	d.Set("created_at", getSnowflakeTime(server.ID))
+
+	botId, botIsOwner, err := isBotOwner(client, server)
+	if err != nil {
+		return diag.Errorf("Failed to fetch bot user: %s", err.Error())
+	}
+	d.Set("bot_is_owner", botIsOwner)
+
+	botPermissions, err := getBotServerPermissions(client, server.ID, botId, botIsOwner)
+	if err != nil {
+		return diag.Errorf("Failed to fetch permissions of the bot in server %s: %s", server.ID.String(), err.Error())
+	}
+	d.Set("bot_permissions", int(botPermissions))
+	d.Set("bot_permission_names", getPermissionNames(botPermissions))
+
+	extras, err := getGuildExtras(ctx, m, server.ID)
+	if err != nil {
+		return diag.Errorf("Failed to fetch server %s: %s", server.ID.String(), err.Error())
+	}
+	if extras.NSFWLevel != nil {
+		d.Set("nsfw_level", *extras.NSFWLevel)
+	} else {
+		d.Set("nsfw_level", 0)
+	}
+	if extras.MaxMembers != nil {
+		d.Set("max_members", *extras.MaxMembers)
+	}
+	if extras.MaxPresences != nil {
+		d.Set("max_presences", *extras.MaxPresences)
+	}
+	if extras.HubType != nil {
+		d.Set("hub_type", *extras.HubType)
+	} else {
+		d.Set("hub_type", 0)
+	}
+
+	if code, ok := getServerVanityURLCode(extras); ok {
+		d.Set("vanity_url_code", code)
+	}
+
+	premiumTier := 0
+	if extras.PremiumTier != nil {
+		premiumTier = *extras.PremiumTier
+	}
+	features := make([]string, 0)
+	if extras.Features != nil {
+		features = *extras.Features
+	}
+	emojiLimit, stickerLimit := getServerAssetLimits(premiumTier, features)
+	d.Set("emoji_limit", emojiLimit)
+	d.Set("sticker_limit", stickerLimit)
+
+	emojis, animatedEmojis := 0, 0
+	if extras.Emojis != nil {
+		for _, e := range *extras.Emojis {
+			if e.Animated {
+				animatedEmojis++
+			} else {
+				emojis++
+			}
+		}
+	}
+	d.Set("emoji_count", emojis)
+	d.Set("animated_emoji_count", animatedEmojis)
+	if extras.Stickers != nil {
+		d.Set("sticker_count", len(*extras.Stickers))
+	} else {
+		d.Set("sticker_count", 0)
+	}
+
 	return diags
 }
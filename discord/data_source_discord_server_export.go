@@ -0,0 +1,247 @@
+package discord
+
+import (
+	"context"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDiscordServerExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDiscordServerExportRead,
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"verification_level": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"explicit_content_filter": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"default_message_notifications": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"afk_channel_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"afk_timeout": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"system_channel_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"position": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"color": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"permissions": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"hoist": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"mentionable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"managed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"channels": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"position": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"topic": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nsfw": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"permission_overwrites": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"overwrite_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"allow": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"deny": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDiscordServerExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	serverId := getId(d.Get("server_id").(string))
+	server, err := client.Guild(serverId).Get()
+	if err != nil {
+		return diag.Errorf("Failed to fetch server %s: %s", serverId.String(), err.Error())
+	}
+
+	roles, err := client.Guild(serverId).GetRoles()
+	if err != nil {
+		return diag.Errorf("Failed to fetch roles of server %s: %s", serverId.String(), err.Error())
+	}
+
+	// The permission overwrites are part of the channel objects, so no request per channel is needed.
+	channels, err := client.Guild(serverId).GetChannels()
+	if err != nil {
+		return diag.Errorf("Failed to fetch channels of server %s: %s", serverId.String(), err.Error())
+	}
+
+	d.SetId(server.ID.String())
+	d.Set("name", server.Name)
+	d.Set("owner_id", server.OwnerID.String())
+	d.Set("verification_level", server.VerificationLevel)
+	d.Set("explicit_content_filter", server.ExplicitContentFilter)
+	d.Set("default_message_notifications", server.DefaultMessageNotifications)
+	d.Set("afk_timeout", server.AfkTimeout)
+	d.Set("afk_channel_id", snowflakeString(server.AfkChannelID))
+	d.Set("system_channel_id", snowflakeString(server.SystemChannelID))
+	d.Set("roles", flattenExportedRoles(roles))
+	d.Set("channels", flattenExportedChannels(channels))
+
+	return diags
+}
+
+// snowflakeString returns an empty string for unset IDs instead of "0".
+func snowflakeString(id disgord.Snowflake) string {
+	if id.IsZero() {
+		return ""
+	}
+
+	return id.String()
+}
+
+func flattenExportedRoles(roles []*disgord.Role) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(roles))
+	for _, r := range roles {
+		result = append(result, map[string]interface{}{
+			"id":          r.ID.String(),
+			"name":        r.Name,
+			"position":    r.Position,
+			"color":       int(r.Color),
+			"permissions": int(r.Permissions),
+			"hoist":       r.Hoist,
+			"mentionable": r.Mentionable,
+			"managed":     r.Managed,
+		})
+	}
+
+	return result
+}
+
+func flattenExportedChannels(channels []*disgord.Channel) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(channels))
+	for _, c := range channels {
+		channelType, _ := getTextChannelType(c.Type)
+
+		overwrites := make([]map[string]interface{}, 0, len(c.PermissionOverwrites))
+		for _, o := range c.PermissionOverwrites {
+			overwriteType, _ := getChannelPermissionTypeName(uint(o.Type))
+			overwrites = append(overwrites, map[string]interface{}{
+				"overwrite_id": o.ID.String(),
+				"type":         overwriteType,
+				"allow":        int(o.Allow),
+				"deny":         int(o.Deny),
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":                    c.ID.String(),
+			"name":                  c.Name,
+			"type":                  channelType,
+			"position":              c.Position,
+			"category":              snowflakeString(c.ParentID),
+			"topic":                 c.Topic,
+			"nsfw":                  c.NSFW,
+			"permission_overwrites": overwrites,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,37 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceServerBotPermissions(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1":           {{status: http.StatusOK, body: `{"id": "1", "name": "server", "owner_id": "3"}`}},
+		"GET /users/@me":          {{status: http.StatusOK, body: `{"id": "2"}`}},
+		"GET /guilds/1/members/2": {{status: http.StatusOK, body: `{"user": {"id": "2"}, "roles": ["5"]}`}},
+		"GET /guilds/1/roles": {{
+			status: http.StatusOK,
+			body:   `[{"id": "1", "position": 0, "permissions": "1024"}, {"id": "5", "position": 1, "permissions": "2048"}]`,
+		}},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceDiscordServer().Schema, map[string]interface{}{"server_id": "1"})
+	if diags := dataSourceDiscordServerRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	// The client already fetched the bot user when it was created, the read fetches it once more for both attributes.
+	if ac := transport.count("GET /users/@me"); ac != 1 {
+		t.Errorf("bot user requests Error: ex: %v, ac: %v", 1, ac)
+	}
+	if ac := d.Get("bot_is_owner").(bool); ac {
+		t.Errorf("bot_is_owner Error: ex: %v, ac: %v", false, ac)
+	}
+	if ac := d.Get("bot_permissions").(int); ac != 1024|2048 {
+		t.Errorf("bot_permissions Error: ex: %v, ac: %v", 1024|2048, ac)
+	}
+}
@@ -0,0 +1,73 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+// discordUser is a user along with the attributes which disgord doesn't model yet.
+type discordUser struct {
+	disgord.User
+	GlobalName *string `json:"global_name"`
+}
+
+func dataSourceDiscordUser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUserRead,
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"global_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"discriminator": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"avatar": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bot": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	userId := getId(d.Get("user_id").(string))
+	var user discordUser
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/users/%s", userId.String()), nil, &user); err != nil {
+		return diag.Errorf("Failed to fetch user %s: %s", userId.String(), err.Error())
+	}
+
+	d.SetId(user.ID.String())
+	d.Set("username", user.Username)
+	d.Set("discriminator", user.Discriminator.String())
+	d.Set("avatar", user.Avatar)
+	d.Set("bot", user.Bot)
+	if user.GlobalName != nil {
+		d.Set("global_name", *user.GlobalName)
+	} else {
+		d.Set("global_name", "")
+	}
+
+	return diags
+}
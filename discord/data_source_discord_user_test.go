@@ -0,0 +1,40 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceUser(t *testing.T) {
+	params := []struct {
+		body       string
+		globalName string
+	}{
+		{body: `{"id": "2", "username": "user", "discriminator": "0", "avatar": "abc", "bot": true, "global_name": "User"}`, globalName: "User"},
+		{body: `{"id": "2", "username": "user", "discriminator": "0", "avatar": "abc", "bot": true, "global_name": null}`, globalName: ""},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /users/2": {{status: http.StatusOK, body: p.body}},
+		})
+
+		d := schema.TestResourceDataRaw(t, dataSourceDiscordUser().Schema, map[string]interface{}{"user_id": "2"})
+		if diags := dataSourceUserRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("body: %v - read Error: ex: %v, ac: %v", p.body, nil, diags)
+		}
+
+		if ac := transport.count("GET /users/2"); ac != 1 {
+			t.Errorf("body: %v - requests Error: ex: %v, ac: %v", p.body, 1, ac)
+		}
+		if d.Id() != "2" || d.Get("username").(string) != "user" || d.Get("avatar").(string) != "abc" || !d.Get("bot").(bool) {
+			t.Errorf("body: %v - user Error: ex: %v, ac: %v", p.body, "user 2 with avatar abc and bot", d.State())
+		}
+		if ac := d.Get("global_name").(string); ac != p.globalName {
+			t.Errorf("body: %v - global_name Error: ex: %v, ac: %v", p.body, p.globalName, ac)
+		}
+	}
+}
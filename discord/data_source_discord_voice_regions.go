@@ -0,0 +1,95 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type voiceRegion struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Optimal    bool   `json:"optimal"`
+	Deprecated bool   `json:"deprecated"`
+	Custom     bool   `json:"custom"`
+}
+
+func dataSourceDiscordVoiceRegions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDiscordVoiceRegionsRead,
+		Schema: map[string]*schema.Schema{
+			"regions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"optimal": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"deprecated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"custom": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getVoiceRegions fetches the voice regions once per run.
+func getVoiceRegions(ctx context.Context, m interface{}) ([]*voiceRegion, error) {
+	c := m.(*Context)
+	c.voiceRegionsMutex.Lock()
+	defer c.voiceRegionsMutex.Unlock()
+
+	if c.voiceRegions == nil {
+		var regions []*voiceRegion
+		if err := discordRequest(ctx, m, http.MethodGet, "/voice/regions", nil, &regions); err != nil {
+			return nil, err
+		}
+		c.voiceRegions = regions
+	}
+
+	return c.voiceRegions, nil
+}
+
+func dataSourceDiscordVoiceRegionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	regions, err := getVoiceRegions(ctx, m)
+	if err != nil {
+		return diag.Errorf("Failed to fetch voice regions: %s", err.Error())
+	}
+
+	data := make([]map[string]interface{}, 0, len(regions))
+	for _, r := range regions {
+		data = append(data, map[string]interface{}{
+			"id":         r.ID,
+			"name":       r.Name,
+			"optimal":    r.Optimal,
+			"deprecated": r.Deprecated,
+			"custom":     r.Custom,
+		})
+	}
+
+	d.SetId("voice_regions")
+	d.Set("regions", data)
+
+	return diags
+}
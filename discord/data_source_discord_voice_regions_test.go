@@ -0,0 +1,33 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestVoiceRegionsCached(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /voice/regions": {{status: http.StatusOK, body: `[
+			{"id": "rotterdam", "name": "Rotterdam", "optimal": true, "deprecated": false, "custom": false},
+			{"id": "europe", "name": "Europe", "optimal": false, "deprecated": true, "custom": false}
+		]`}},
+	})
+
+	r := dataSourceDiscordVoiceRegions()
+	for i := 0; i < 2; i++ {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		if diags := dataSourceDiscordVoiceRegionsRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+		}
+		if ac := d.Get("regions.1.deprecated").(bool); !ac {
+			t.Errorf("deprecated Error: ex: %v, ac: %v", true, ac)
+		}
+	}
+
+	if ac := transport.count("GET /voice/regions"); ac != 1 {
+		t.Errorf("requests Error: ex: %v, ac: %v", 1, ac)
+	}
+}
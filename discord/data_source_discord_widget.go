@@ -0,0 +1,137 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type widgetChannel struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type widgetMember struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Status   string `json:"status"`
+}
+
+type widget struct {
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	InstantInvite string           `json:"instant_invite"`
+	Channels      []*widgetChannel `json:"channels"`
+	Members       []*widgetMember  `json:"members"`
+	PresenceCount int              `json:"presence_count"`
+}
+
+func dataSourceDiscordWidget() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDiscordWidgetRead,
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instant_invite": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"presence_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"channels": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"position": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDiscordWidgetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := getId(d.Get("server_id").(string))
+
+	var w widget
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s/widget.json", serverId.String()), nil, &w); err != nil {
+		if isDiscordError(err, discordErrorWidgetDisabled) {
+			return diag.Errorf("The widget of server %s is disabled, enable it in the server settings first", serverId.String())
+		}
+		return diag.Errorf("Failed to fetch widget of server %s: %s", serverId.String(), err.Error())
+	}
+
+	channels := make([]map[string]interface{}, 0, len(w.Channels))
+	for _, c := range w.Channels {
+		channels = append(channels, map[string]interface{}{
+			"id":       c.ID,
+			"name":     c.Name,
+			"position": c.Position,
+		})
+	}
+
+	// Discord anonymizes the members of the widget, their IDs are only valid for the widget.
+	members := make([]map[string]interface{}, 0, len(w.Members))
+	for _, u := range w.Members {
+		members = append(members, map[string]interface{}{
+			"id":       u.ID,
+			"username": u.Username,
+			"status":   u.Status,
+		})
+	}
+
+	d.SetId(serverId.String())
+	d.Set("name", w.Name)
+	d.Set("instant_invite", w.InstantInvite)
+	d.Set("presence_count", w.PresenceCount)
+	d.Set("channels", channels)
+	d.Set("members", members)
+
+	return diags
+}
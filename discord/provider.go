@@ -2,13 +2,14 @@ package discord
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"token": {
 				Type:     schema.TypeString,
@@ -22,22 +23,48 @@ func Provider() *schema.Provider {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"debug": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DISCORD_DEBUG", false),
+				Description: "Log every request to the Discord API at the DEBUG level, tokens are redacted.",
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DISCORD_DRY_RUN", false),
+				Description: "Log the requests which would change something on Discord instead of sending them.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"discord_server":             resourceDiscordServer(),
-			"discord_managed_server":     resourceDiscordManagedServer(),
-			"discord_category_channel":   resourceDiscordCategoryChannel(),
-			"discord_text_channel":       resourceDiscordTextChannel(),
-			"discord_voice_channel":      resourceDiscordVoiceChannel(),
-			"discord_news_channel":       resourceDiscordNewsChannel(),
-			"discord_channel_permission": resourceDiscordChannelPermission(),
-			"discord_invite":             resourceDiscordInvite(),
-			"discord_role":               resourceDiscordRole(),
-			"discord_role_everyone":      resourceDiscordRoleEveryone(),
-			"discord_member_roles":       resourceDiscordMemberRoles(),
-			"discord_message":            resourceDiscordMessage(),
-			"discord_system_channel":     resourceDiscordSystemChannel(),
+			"discord_server":                          resourceDiscordServer(),
+			"discord_managed_server":                  resourceDiscordManagedServer(),
+			"discord_category_channel":                resourceDiscordCategoryChannel(),
+			"discord_text_channel":                    resourceDiscordTextChannel(),
+			"discord_voice_channel":                   resourceDiscordVoiceChannel(),
+			"discord_news_channel":                    resourceDiscordNewsChannel(),
+			"discord_forum_channel":                   resourceDiscordForumChannel(),
+			"discord_channel_permission":              resourceDiscordChannelPermission(),
+			"discord_invite":                          resourceDiscordInvite(),
+			"discord_role":                            resourceDiscordRole(),
+			"discord_role_everyone":                   resourceDiscordRoleEveryone(),
+			"discord_role_order":                      resourceDiscordRoleOrder(),
+			"discord_member_roles":                    resourceDiscordMemberRoles(),
+			"discord_member_roles_bulk":               resourceDiscordMemberRolesBulk(),
+			"discord_message":                         resourceDiscordMessage(),
+			"discord_system_channel":                  resourceDiscordSystemChannel(),
+			"discord_guild_prune":                     resourceDiscordGuildPrune(),
+			"discord_integration_settings":            resourceDiscordIntegrationSettings(),
+			"discord_application_command_permissions": resourceDiscordApplicationCommandPermissions(),
+			"discord_incident_actions":                resourceDiscordIncidentActions(),
+			"discord_bans":                            resourceDiscordBans(),
+			"discord_guild_voice_state":               resourceDiscordGuildVoiceState(),
+			"discord_guild_discovery":                 resourceDiscordGuildDiscovery(),
+			"discord_guild_emojis":                    resourceDiscordGuildEmojis(),
+			"discord_message_bulk_delete":             resourceDiscordMessageBulkDelete(),
+			"discord_channel_layout":                  resourceDiscordChannelLayout(),
+			"discord_server_owner":                    resourceDiscordServerOwner(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -48,17 +75,75 @@ func Provider() *schema.Provider {
 			"discord_server":         dataSourceDiscordServer(),
 			"discord_member":         dataSourceDiscordMember(),
 			"discord_system_channel": dataSourceDiscordSystemChannel(),
+			"discord_audit_log":      dataSourceDiscordAuditLog(),
+			"discord_server_export":  dataSourceDiscordServerExport(),
+			"discord_widget":         dataSourceDiscordWidget(),
+			"discord_user":           dataSourceDiscordUser(),
+			"discord_voice_regions":  dataSourceDiscordVoiceRegions(),
+			"discord_channel":        dataSourceDiscordChannel(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for _, r := range p.ResourcesMap {
+		withDryRun(r)
+	}
+
+	return p
+}
+
+// withDryRun keeps the state of a resource unchanged during a dry run. Its requests succeed without being sent,
+// so the operation runs to the end and then fails, as Terraform would otherwise record changes that never happened.
+func withDryRun(r *schema.Resource) {
+	if create := r.CreateContext; create != nil {
+		r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			diags := create(ctx, d, m)
+			if m.(*Context).Config.DryRun {
+				d.SetId("")
+				diags = append(diags, dryRunError("creation"))
+			}
+
+			return diags
+		}
+	}
+	if update := r.UpdateContext; update != nil {
+		r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if !m.(*Context).Config.DryRun {
+				return update(ctx, d, m)
+			}
+
+			d.Partial(true)
+			return append(update(ctx, d, m), dryRunError("update"))
+		}
+	}
+	if del := r.DeleteContext; del != nil {
+		r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			diags := del(ctx, d, m)
+			if m.(*Context).Config.DryRun {
+				diags = append(diags, dryRunError("deletion"))
+			}
+
+			return diags
+		}
+	}
+}
+
+func dryRunError(operation string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Dry run, the %s was not sent to Discord", operation),
+		Detail:   "The requests it would have sent are logged with their payloads at the WARN level. The state is left unchanged.",
+	}
 }
 
 func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	config := Config{
-		Token: d.Get("token").(string),
+		Token:  d.Get("token").(string),
+		Debug:  d.Get("debug").(bool),
+		DryRun: d.Get("dry_run").(bool),
 	}
 
 	client, err := config.Client()
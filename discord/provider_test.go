@@ -0,0 +1,278 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type mockResponse struct {
+	status int
+	body   string
+	header http.Header
+}
+
+// mockTransport serves canned responses keyed by "METHOD /path", the API version prefix is ignored.
+// Responses of a route are served in order and the last one is repeated.
+type mockTransport struct {
+	routes   map[string][]mockResponse
+	requests []string
+	bodies   []string
+	headers  []http.Header
+	queries  []string
+}
+
+var apiVersionPrefix = regexp.MustCompile(`^/api/v\d+`)
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := req.Method + " " + apiVersionPrefix.ReplaceAllString(req.URL.Path, "")
+	t.requests = append(t.requests, route)
+	body := ""
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+	t.bodies = append(t.bodies, body)
+	t.headers = append(t.headers, req.Header.Clone())
+	t.queries = append(t.queries, req.URL.RawQuery)
+
+	responses, ok := t.routes[route]
+	if !ok || len(responses) == 0 {
+		responses = []mockResponse{{status: http.StatusNotFound, body: `{"code": 0, "message": "404: Not Found"}`}}
+	}
+	res := responses[0]
+	if len(responses) > 1 {
+		t.routes[route] = responses[1:]
+	}
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	for k, v := range res.header {
+		header[k] = v
+	}
+
+	return &http.Response{
+		StatusCode: res.status,
+		Status:     http.StatusText(res.status),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(res.body)),
+		Request:    req,
+	}, nil
+}
+
+func (t *mockTransport) count(route string) int {
+	n := 0
+	for _, r := range t.requests {
+		if r == route {
+			n++
+		}
+	}
+
+	return n
+}
+
+// newMockTransport serves the given routes, along with the bot user which disgord fetches to check the token
+// when the client is created. Tests may answer that route themselves.
+func newMockTransport(routes map[string][]mockResponse) *mockTransport {
+	if _, ok := routes["GET /users/@me"]; !ok {
+		routes["GET /users/@me"] = []mockResponse{{status: http.StatusOK, body: `{"id": "100", "username": "bot", "bot": true}`}}
+	}
+
+	return &mockTransport{routes: routes}
+}
+
+// newTestClient creates the client of the config, whose transport has to be a mockTransport. The requests disgord
+// sends while creating the client are forgotten, so that tests only see the requests of the code under test.
+func newTestClient(t *testing.T, config *Config) *Context {
+	c, err := config.Client()
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err.Error())
+	}
+
+	transport := config.Transport.(*mockTransport)
+	transport.requests, transport.bodies, transport.headers, transport.queries = nil, nil, nil, nil
+
+	return c
+}
+
+func newTestContext(t *testing.T, routes map[string][]mockResponse) (*Context, *mockTransport) {
+	transport := newMockTransport(routes)
+
+	return newTestClient(t, &Config{Token: "test-token", Transport: transport}), transport
+}
+
+// testResourceDataDiff builds the data an update sees, with the changes planned from the state to the configuration.
+// Setting attributes on data read from a state doesn't make them changes.
+func testResourceDataDiff(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}, m interface{}) *schema.ResourceData {
+	state.RawConfig = testRawConfig(t, r, config)
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), m)
+	if err != nil {
+		t.Fatalf("diff Error: ex: %v, ac: %v", nil, err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("data Error: ex: %v, ac: %v", nil, err)
+	}
+
+	return d
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestLimitedRoundTripperRetriesRateLimits(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1": {
+			{status: http.StatusTooManyRequests, body: `{"message": "You are being rate limited.", "retry_after": 0}`, header: http.Header{"Retry-After": []string{"0"}}},
+			{status: http.StatusOK, body: `{"id": "1"}`},
+		},
+	})
+
+	var guild struct {
+		ID string `json:"id"`
+	}
+	if err := discordRequest(context.Background(), c, http.MethodGet, "/guilds/1", nil, &guild); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if n := transport.count("GET /guilds/1"); n != 2 {
+		t.Errorf("requests Error: ex: %v, ac: %v", 2, n)
+	}
+	if guild.ID != "1" {
+		t.Errorf("id Error: ex: %v, ac: %v", "1", guild.ID)
+	}
+}
+
+func TestDebugLogRedactsTokens(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	transport := newMockTransport(map[string][]mockResponse{
+		"GET /webhooks/1/webhook-token": {{status: http.StatusOK, body: `{"id": "1"}`, header: http.Header{"X-Ratelimit-Remaining": []string{"4"}}}},
+	})
+	c := newTestClient(t, &Config{Token: "test-token", Transport: transport, Debug: true})
+
+	if err := discordRequest(context.Background(), c, http.MethodGet, "/webhooks/1/webhook-token", nil, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	logged := buf.String()
+	if !strings.Contains(logged, "GET /api/v10/webhooks/1/<redacted>: 200") {
+		t.Errorf("log Error: ex: %v, ac: %v", "GET /api/v10/webhooks/1/<redacted>: 200", logged)
+	}
+	if !strings.Contains(logged, `remaining: "4"`) {
+		t.Errorf("log Error: ex: %v, ac: %v", `remaining: "4"`, logged)
+	}
+	for _, secret := range []string{"webhook-token", "test-token"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log Error: ex: %v, ac: %v", "no "+secret, logged)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	transport := newMockTransport(map[string][]mockResponse{
+		"GET /guilds/1":   {{status: http.StatusOK, body: `{"id": "1"}`}},
+		"PATCH /guilds/1": {{status: http.StatusOK, body: `{"id": "1"}`}},
+	})
+	c := newTestClient(t, &Config{Token: "test-token", Transport: transport, DryRun: true})
+
+	if err := discordRequest(context.Background(), c, http.MethodGet, "/guilds/1", nil, nil); err != nil {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, err)
+	}
+
+	var out map[string]string
+	if err := discordRequest(context.Background(), c, http.MethodPatch, "/guilds/1", map[string]string{"name": "renamed"}, &out); err != nil {
+		t.Errorf("write Error: ex: %v, ac: %v", nil, err)
+	}
+	if out["name"] != "renamed" {
+		t.Errorf("echo Error: ex: %v, ac: %v", "renamed", out)
+	}
+	if err := discordRequest(context.Background(), c, http.MethodDelete, "/guilds/1", nil, nil); err != nil {
+		t.Errorf("delete Error: ex: %v, ac: %v", nil, err)
+	}
+	if ac := transport.count("PATCH /guilds/1") + transport.count("DELETE /guilds/1"); ac != 0 {
+		t.Errorf("requests Error: ex: %v, ac: %v", 0, ac)
+	}
+}
+
+func TestDryRunResource(t *testing.T) {
+	transport := newMockTransport(map[string][]mockResponse{
+		"GET /guilds/1":       {{status: http.StatusOK, body: `{"id": "1", "roles": [{"id": "5", "name": "role", "position": 1}]}`}},
+		"GET /guilds/1/roles": {{status: http.StatusOK, body: `[{"id": "5", "name": "role", "position": 1}]`}},
+	})
+	c := newTestClient(t, &Config{Token: "test-token", Transport: transport, DryRun: true})
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	r := Provider().ResourcesMap["discord_role"]
+	raw := map[string]interface{}{"server_id": "1", "name": "renamed"}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(context.Background(), d, c); !diags.HasError() || d.Id() != "" {
+		t.Errorf("create Error: ex: %v, ac: %v %q", "dry run error and no ID", diags, d.Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId("5")
+	if diags := r.UpdateContext(context.Background(), d, c); !diags.HasError() {
+		t.Errorf("update Error: ex: %v, ac: %v", "dry run error", diags)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, c); !diags.HasError() {
+		t.Errorf("delete Error: ex: %v, ac: %v", "dry run error", diags)
+	}
+
+	for _, route := range []string{"POST /guilds/1/roles", "PATCH /guilds/1/roles/5", "DELETE /guilds/1/roles/5"} {
+		if ac := transport.count(route); ac != 0 {
+			t.Errorf("%s requests Error: ex: %v, ac: %v", route, 0, ac)
+		}
+		method, path, _ := strings.Cut(route, " ")
+		if !regexp.MustCompile(`Dry run, not sending Discord API ` + method + ` /api/v\d+` + path).MatchString(logged.String()) {
+			t.Errorf("%s log Error: ex: %v, ac: %v", route, "logged", logged.String())
+		}
+	}
+	if !strings.Contains(logged.String(), `"name":"renamed"`) {
+		t.Errorf("payload log Error: ex: %v, ac: %v", `"name":"renamed"`, logged.String())
+	}
+}
+
+func TestDiscordRequestError(t *testing.T) {
+	c, _ := newTestContext(t, map[string][]mockResponse{
+		"DELETE /channels/1": {{status: http.StatusNotFound, body: `{"code": 10003, "message": "Unknown Channel"}`}},
+	})
+
+	err := discordRequest(context.Background(), c, http.MethodDelete, "/channels/1", nil, nil)
+
+	var apiErr *discordAPIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err Error: ex: %T, ac: %T", apiErr, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode Error: ex: %v, ac: %v", http.StatusNotFound, apiErr.StatusCode)
+	}
+	if !isDiscordError(err, discordErrorUnknownChannel) {
+		t.Errorf("isDiscordError Error: ex: %v, ac: %v", true, false)
+	}
+	if isDiscordError(err, discordErrorUnknownRole) {
+		t.Errorf("isDiscordError Error: ex: %v, ac: %v", false, true)
+	}
+}
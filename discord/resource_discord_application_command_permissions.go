@@ -0,0 +1,175 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+type applicationCommandPermission struct {
+	ID         string `json:"id"`
+	Type       int    `json:"type"`
+	Permission bool   `json:"permission"`
+}
+
+type applicationCommandPermissions struct {
+	Permissions []*applicationCommandPermission `json:"permissions"`
+}
+
+func getApplicationCommandPermissionTypeName(value int) (string, bool) {
+	switch value {
+	case 1:
+		return "role", true
+	case 2:
+		return "user", true
+	case 3:
+		return "channel", true
+	}
+
+	return "role", false
+}
+
+func getDiscordApplicationCommandPermissionType(name string) (int, bool) {
+	switch name {
+	case "role":
+		return 1, true
+	case "user":
+		return 2, true
+	case "channel":
+		return 3, true
+	}
+
+	return 0, false
+}
+
+func resourceDiscordApplicationCommandPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceApplicationCommandPermissionsUpdate,
+		ReadContext:   resourceApplicationCommandPermissionsRead,
+		UpdateContext: resourceApplicationCommandPermissionsUpdate,
+		DeleteContext: resourceApplicationCommandPermissionsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"command_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Discord only accepts a bearer token of a server admin for editing the permissions, the bot token is rejected.
+			"access_token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "OAuth2 bearer token with the applications.commands.permissions.update scope.",
+			},
+			"permission": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 100,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+								v := val.(string)
+								if _, ok := getDiscordApplicationCommandPermissionType(v); !ok {
+									errors = append(errors, fmt.Errorf("%s is not a valid type. Must be \"role\", \"user\" or \"channel\"", v))
+								}
+
+								return
+							},
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"allow": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getApplicationCommandPermissionsPath(d *schema.ResourceData) string {
+	return fmt.Sprintf("/applications/%s/guilds/%s/commands/%s/permissions",
+		d.Get("application_id").(string), d.Get("server_id").(string), d.Get("command_id").(string))
+}
+
+func putApplicationCommandPermissions(ctx context.Context, d *schema.ResourceData, m interface{}, permissions []*applicationCommandPermission) error {
+	return discordRequestWithAuthorization(ctx, m, "Bearer "+d.Get("access_token").(string), http.MethodPut,
+		getApplicationCommandPermissionsPath(d), &applicationCommandPermissions{Permissions: permissions}, nil)
+}
+
+func resourceApplicationCommandPermissionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Commands without any permissions set are reported as unknown.
+	var res applicationCommandPermissions
+	if err := discordRequest(ctx, m, http.MethodGet, getApplicationCommandPermissionsPath(d), nil, &res); err != nil && !isDiscordError(err, discordErrorUnknownCommandPermissions) {
+		return diag.Errorf("Failed to fetch permissions of command %s: %s", d.Get("command_id").(string), err.Error())
+	}
+
+	permissions := make([]map[string]interface{}, 0, len(res.Permissions))
+	for _, p := range res.Permissions {
+		permissionType, _ := getApplicationCommandPermissionTypeName(p.Type)
+		permissions = append(permissions, map[string]interface{}{
+			"type":  permissionType,
+			"id":    p.ID,
+			"allow": p.Permission,
+		})
+	}
+	d.Set("permission", permissions)
+
+	return diags
+}
+
+func resourceApplicationCommandPermissionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	permissions := make([]*applicationCommandPermission, 0)
+	for _, v := range d.Get("permission").(*schema.Set).List() {
+		p := v.(map[string]interface{})
+		permissionType, _ := getDiscordApplicationCommandPermissionType(p["type"].(string))
+		permissions = append(permissions, &applicationCommandPermission{
+			ID:         p["id"].(string),
+			Type:       permissionType,
+			Permission: p["allow"].(bool),
+		})
+	}
+
+	if err := putApplicationCommandPermissions(ctx, d, m, permissions); err != nil {
+		return diag.Errorf("Failed to set permissions of command %s: %s", d.Get("command_id").(string), err.Error())
+	}
+
+	d.SetId(generateTwoPartId(d.Get("server_id").(string), d.Get("command_id").(string)))
+
+	return diags
+}
+
+func resourceApplicationCommandPermissionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := putApplicationCommandPermissions(ctx, d, m, []*applicationCommandPermission{}); err != nil {
+		return diag.Errorf("Failed to reset permissions of command %s: %s", d.Get("command_id").(string), err.Error())
+	}
+
+	return diags
+}
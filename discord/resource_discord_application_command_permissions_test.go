@@ -0,0 +1,83 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestApplicationCommandPermissionsUpdate(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"PUT /applications/1/guilds/2/commands/3/permissions": {{status: http.StatusOK, body: `{"id": "3", "permissions": []}`}},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceDiscordApplicationCommandPermissions().Schema, map[string]interface{}{
+		"application_id": "1",
+		"server_id":      "2",
+		"command_id":     "3",
+		"access_token":   "bearer-token",
+		"permission": []interface{}{
+			map[string]interface{}{"type": "channel", "id": "4", "allow": false},
+		},
+	})
+
+	if diags := resourceApplicationCommandPermissionsUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("update Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := transport.count("PUT /applications/1/guilds/2/commands/3/permissions"); ac != 1 {
+		t.Fatalf("requests Error: ex: %v, ac: %v", 1, transport.requests)
+	}
+
+	payload := `{"permissions":[{"id":"4","type":3,"permission":false}]}`
+	if ac := transport.bodies[0]; ac != payload {
+		t.Errorf("payload Error: ex: %v, ac: %v", payload, ac)
+	}
+	if ac := transport.headers[0].Get("Authorization"); ac != "Bearer bearer-token" {
+		t.Errorf("authorization Error: ex: %v, ac: %v", "Bearer bearer-token", ac)
+	}
+	if ac := d.Id(); ac != "2:3" {
+		t.Errorf("id Error: ex: %v, ac: %v", "2:3", ac)
+	}
+}
+
+func TestApplicationCommandPermissionsRead(t *testing.T) {
+	params := []struct {
+		response    mockResponse
+		permissions int
+		err         bool
+	}{
+		{response: mockResponse{status: http.StatusOK, body: `{"id": "3", "permissions": [{"id": "4", "type": 1, "permission": true}, {"id": "5", "type": 2, "permission": false}]}`}, permissions: 2},
+		{response: mockResponse{status: http.StatusNotFound, body: `{"code": 10066, "message": "Unknown application command permissions"}`}, permissions: 0},
+		{response: mockResponse{status: http.StatusForbidden, body: `{"code": 50001, "message": "Missing Access"}`}, err: true},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /applications/1/guilds/2/commands/3/permissions": {p.response},
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceDiscordApplicationCommandPermissions().Schema, map[string]interface{}{
+			"application_id": "1",
+			"server_id":      "2",
+			"command_id":     "3",
+			"access_token":   "bearer-token",
+			"permission": []interface{}{
+				map[string]interface{}{"type": "role", "id": "4", "allow": true},
+			},
+		})
+		d.SetId("2:3")
+
+		diags := resourceApplicationCommandPermissionsRead(context.Background(), d, c)
+		if diags.HasError() != p.err {
+			t.Fatalf("status: %v - read Error: ex: %v, ac: %v", p.response.status, p.err, diags)
+		}
+		if p.err {
+			continue
+		}
+		if ac := d.Get("permission").(*schema.Set).Len(); ac != p.permissions {
+			t.Errorf("status: %v - permission Error: ex: %v, ac: %v", p.response.status, p.permissions, ac)
+		}
+	}
+}
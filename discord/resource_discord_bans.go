@@ -0,0 +1,221 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+type serverBan struct {
+	Reason *string `json:"reason"`
+	User   struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+// banPageSize is the largest number of bans Discord returns at once.
+var banPageSize = 1000
+
+func resourceDiscordBans() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBansCreate,
+		ReadContext:   resourceBansRead,
+		UpdateContext: resourceBansUpdate,
+		DeleteContext: resourceBansDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBansImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ban": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"reason": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"exclusive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceBansImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	data.Set("server_id", data.Id())
+
+	return schema.ImportStatePassthroughContext(ctx, data, i)
+}
+
+// getServerBans fetches every ban of a server, page by page, keyed by the ID of the banned user.
+func getServerBans(ctx context.Context, m interface{}, serverId string) (map[string]string, error) {
+	bans := make(map[string]string)
+	after := ""
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(banPageSize))
+		if after != "" {
+			query.Set("after", after)
+		}
+
+		var page []*serverBan
+		path := fmt.Sprintf("/guilds/%s/bans?%s", serverId, query.Encode())
+		if err := discordRequest(ctx, m, http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+
+		for _, ban := range page {
+			reason := ""
+			if ban.Reason != nil {
+				reason = *ban.Reason
+			}
+			bans[ban.User.ID] = reason
+			after = ban.User.ID
+		}
+
+		if len(page) < banPageSize {
+			return bans, nil
+		}
+	}
+}
+
+// getConfiguredBans returns the reasons of the bans in the ban blocks, keyed by user ID.
+func getConfiguredBans(set *schema.Set) map[string]string {
+	bans := make(map[string]string, set.Len())
+	for _, b := range set.List() {
+		ban := b.(map[string]interface{})
+		bans[ban["user_id"].(string)] = ban["reason"].(string)
+	}
+
+	return bans
+}
+
+func resourceBansCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(d.Get("server_id").(string))
+
+	diags = append(diags, resourceBansUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceBansRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	banned, err := getServerBans(ctx, m, serverId)
+	if err != nil {
+		return diag.Errorf("Failed to fetch bans for %s: %s", serverId, err.Error())
+	}
+
+	// The reason can't be changed once a user is banned, so the configured one is kept
+	// and only the bans which aren't configured take their reason from Discord.
+	configured := getConfiguredBans(d.Get("ban").(*schema.Set))
+	bans := make([]map[string]interface{}, 0, len(configured))
+	for userId, reason := range configured {
+		if _, ok := banned[userId]; ok {
+			bans = append(bans, map[string]interface{}{"user_id": userId, "reason": reason})
+		}
+	}
+	if d.Get("exclusive").(bool) {
+		for userId, reason := range banned {
+			if _, ok := configured[userId]; !ok {
+				bans = append(bans, map[string]interface{}{"user_id": userId, "reason": reason})
+			}
+		}
+	}
+
+	d.Set("ban", bans)
+
+	return diags
+}
+
+func resourceBansUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	banned, err := getServerBans(ctx, m, serverId)
+	if err != nil {
+		return diag.Errorf("Failed to fetch bans for %s: %s", serverId, err.Error())
+	}
+
+	wanted := getConfiguredBans(d.Get("ban").(*schema.Set))
+
+	for userId, reason := range wanted {
+		if _, ok := banned[userId]; ok {
+			continue
+		}
+		path := fmt.Sprintf("/guilds/%s/bans/%s", serverId, userId)
+		if err := discordRequestWithHeaders(ctx, m, auditLogReason(reason), http.MethodPut, path, nil, nil); err != nil {
+			return diag.Errorf("Failed to ban user %s from server %s: %s", userId, serverId, err.Error())
+		}
+	}
+
+	// Only exclusive mode unbans, which covers the users removed from the list as they are still banned.
+	if d.Get("exclusive").(bool) {
+		for userId := range banned {
+			if _, ok := wanted[userId]; ok {
+				continue
+			}
+			if err := unbanUser(ctx, m, serverId, userId); err != nil {
+				return diag.Errorf("Failed to unban user %s from server %s: %s", userId, serverId, err.Error())
+			}
+		}
+	}
+
+	diags = append(diags, resourceBansRead(ctx, d, m)...)
+
+	return diags
+}
+
+func unbanUser(ctx context.Context, m interface{}, serverId string, userId string) error {
+	return discordRequest(ctx, m, http.MethodDelete, fmt.Sprintf("/guilds/%s/bans/%s", serverId, userId), nil, nil)
+}
+
+func resourceBansDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Like users removed from the list, the bans stay unless the resource is exclusive.
+	if !d.Get("exclusive").(bool) {
+		return diags
+	}
+
+	serverId := d.Get("server_id").(string)
+	banned, err := getServerBans(ctx, m, serverId)
+	if err != nil {
+		return diag.Errorf("Failed to fetch bans for %s: %s", serverId, err.Error())
+	}
+
+	for userId := range getConfiguredBans(d.Get("ban").(*schema.Set)) {
+		if _, ok := banned[userId]; !ok {
+			continue
+		}
+		if err := unbanUser(ctx, m, serverId, userId); err != nil {
+			return diag.Errorf("Failed to unban user %s from server %s: %s", userId, serverId, err.Error())
+		}
+	}
+
+	return diags
+}
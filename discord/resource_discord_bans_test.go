@@ -0,0 +1,97 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestBansExclusive(t *testing.T) {
+	banPageSize = 1
+	defer func() { banPageSize = 1000 }()
+
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/bans": {
+			{status: http.StatusOK, body: `[{"reason": "spam", "user": {"id": "2"}}]`},
+			{status: http.StatusOK, body: `[{"reason": null, "user": {"id": "3"}}]`},
+			{status: http.StatusOK, body: `[]`},
+		},
+		"PUT /guilds/1/bans/4":    {{status: http.StatusNoContent}},
+		"DELETE /guilds/1/bans/3": {{status: http.StatusNoContent}},
+	})
+
+	r := resourceDiscordBans()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id": "1",
+		"exclusive": true,
+		"ban": []interface{}{
+			map[string]interface{}{"user_id": "2", "reason": "spam"},
+			map[string]interface{}{"user_id": "4", "reason": "raid"},
+		},
+	})
+
+	if diags := resourceBansCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	if ac := transport.count("GET /guilds/1/bans"); ac < 3 {
+		t.Errorf("pagination Error: ex: %v, ac: %v", "at least 3 pages", ac)
+	}
+	if ac := transport.count("PUT /guilds/1/bans/4"); ac != 1 {
+		t.Errorf("ban Error: ex: %v, ac: %v", 1, ac)
+	}
+	if ac := transport.count("PUT /guilds/1/bans/2"); ac != 0 {
+		t.Errorf("already banned Error: ex: %v, ac: %v", 0, ac)
+	}
+	if ac := transport.count("DELETE /guilds/1/bans/3"); ac != 1 {
+		t.Errorf("unban Error: ex: %v, ac: %v", 1, ac)
+	}
+}
+
+func TestBansRemovedUserStaysBanned(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/bans": {{status: http.StatusOK, body: `[{"reason": "spam", "user": {"id": "2"}}, {"reason": "raid", "user": {"id": "3"}}]`}},
+	})
+
+	r := resourceDiscordBans()
+	state := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id": "1",
+		"ban": []interface{}{
+			map[string]interface{}{"user_id": "2", "reason": "spam"},
+			map[string]interface{}{"user_id": "3", "reason": "raid"},
+		},
+	})
+	state.SetId("1")
+	config := map[string]interface{}{
+		"server_id": "1",
+		"ban":       []interface{}{map[string]interface{}{"user_id": "2", "reason": "spam"}},
+	}
+	diff, err := r.Diff(context.Background(), state.State(), terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatalf("diff Error: ex: %v, ac: %v", nil, err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state.State(), diff)
+	if err != nil {
+		t.Fatalf("data Error: ex: %v, ac: %v", nil, err)
+	}
+
+	if diags := resourceBansUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("update Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := transport.count("DELETE /guilds/1/bans/3"); ac != 0 {
+		t.Errorf("unban Error: ex: %v, ac: %v", 0, ac)
+	}
+	if ac := d.Get("ban").(*schema.Set).Len(); ac != 1 {
+		t.Errorf("ban Error: ex: %v, ac: %v", 1, ac)
+	}
+
+	if diags := resourceBansDelete(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("delete Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := transport.count("DELETE /guilds/1/bans/2"); ac != 0 {
+		t.Errorf("destroy Error: ex: %v, ac: %v", 0, ac)
+	}
+}
@@ -10,8 +10,9 @@ func resourceDiscordCategoryChannel() *schema.Resource {
 		ReadContext:   resourceChannelRead,
 		UpdateContext: resourceChannelUpdate,
 		DeleteContext: resourceChannelDelete,
+		CustomizeDiff: customizeChannelDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceChannelImport("category"),
 		},
 		Schema: getChannelSchema("category", nil),
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/andersfylling/disgord"
 	"github.com/hashicorp/go-cty/cty"
@@ -52,16 +53,93 @@ func getChannelSchema(channelType string, s map[string]*schema.Schema) map[strin
 		},
 	}
 
+	// Names which only differ from the one Discord stored by its normalization are the same name.
+	if hasNormalizedChannelName(channelType) {
+		addedSchema["name"].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			return old != "" && normalizeChannelName(old) == normalizeChannelName(new)
+		}
+	}
+
 	if channelType != "category" {
 		addedSchema["category"] = &schema.Schema{
 			Type:     schema.TypeString,
 			Optional: true,
 		}
+		addedSchema["position_within_category"] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Place of the channel among the channels of its category, starting at 1. position is resolved from it.",
+			ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+				if v := val.(int); v < 1 {
+					errors = append(errors, fmt.Errorf("%s must be at least 1, got: %d", key, v))
+				}
+
+				return
+			},
+		}
+		// The absolute position is resolved from position_within_category when it's set.
+		addedSchema["position"].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			_, ok := d.GetOk("position_within_category")
+			return ok
+		}
 		addedSchema["sync_perms_with_category"] = &schema.Schema{
 			Type:     schema.TypeBool,
 			Optional: true,
 			Default:  true,
 		}
+		addedSchema["permissions_synced"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the channel's permission overwrites match its category's, false without a category.",
+		}
+		// For forum channels this is the ID of the most recent post.
+		addedSchema["last_message_id"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+	}
+
+	addedSchema["unique_name"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the plan fails when another channel of the same type in the same category has this name.",
+	}
+
+	// Only the overwrites configured here are tracked, the ones managed by discord_channel_permission are left alone.
+	addedSchema["permission_overwrite"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+						v := val.(string)
+						if _, ok := getDiscordChannelPermissionType(v); !ok {
+							errors = append(errors, fmt.Errorf("%s is not a valid type. Must be \"role\" or \"user\"", v))
+						}
+
+						return
+					},
+				},
+				"overwrite_id": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"allow": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  0,
+				},
+				"deny": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  0,
+				},
+			},
+		},
 	}
 
 	for k, v := range s {
@@ -71,6 +149,27 @@ func getChannelSchema(channelType string, s map[string]*schema.Schema) map[strin
 	return addedSchema
 }
 
+// Slowmode is limited to 6 hours by Discord.
+func validateRateLimitPerUser(val interface{}, key string) (warns []string, errors []error) {
+	v := val.(int)
+	if v < 0 || v > 21600 {
+		errors = append(errors, fmt.Errorf("%s must be between 0 and 21600 inclusive, got: %d", key, v))
+	}
+
+	return
+}
+
+// Discord counts the topic length in characters, 1024 for text channels and 4096 for forum guidelines.
+func validateTopicLength(max int) schema.SchemaValidateFunc {
+	return func(val interface{}, key string) (warns []string, errors []error) {
+		if l := utf8.RuneCountInString(val.(string)); l > max {
+			errors = append(errors, fmt.Errorf("%s must be at most %d characters, got: %d", key, max, l))
+		}
+
+		return
+	}
+}
+
 func validateChannel(d *schema.ResourceData) (bool, error) {
 	channelType := d.Get("type").(string)
 
@@ -89,11 +188,8 @@ func validateChannel(d *schema.ResourceData) (bool, error) {
 			if _, ok := d.GetOk("topic"); ok {
 				return false, errors.New("topic is not allowed on voice channels")
 			}
-			if _, ok := d.GetOk("nsfw"); ok {
-				return false, errors.New("nsfw is not allowed on voice channels")
-			}
 		}
-	case "text", "news":
+	case "text", "news", "forum":
 		{
 			if _, ok := d.GetOk("bitrate"); ok {
 				return false, errors.New("bitrate is not allowed on text channels")
@@ -103,16 +199,130 @@ func validateChannel(d *schema.ResourceData) (bool, error) {
 					return false, errors.New("user_limit is not allowed on text channels")
 				}
 			}
-			name := d.Get("name").(string)
-			if strings.ToLower(name) != name {
-				return false, errors.New("name must be lowercase")
-			}
 		}
 	}
 
 	return true, nil
 }
 
+// findChannelNameCollision returns another channel of the same type in the same category with the same name, if there is one.
+// Discord normalizes the names of text channels, so names are compared normalized and case-insensitively.
+func findChannelNameCollision(client *disgord.Client, serverId disgord.Snowflake, channelId disgord.Snowflake, channelType string, categoryId disgord.Snowflake, name string) (*disgord.Channel, error) {
+	channels, err := client.Guild(serverId).GetChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	if hasNormalizedChannelName(channelType) {
+		name = normalizeChannelName(name)
+	}
+
+	for _, channel := range channels {
+		if t, ok := getTextChannelType(channel.Type); !ok || t != channelType || channel.ID == channelId {
+			continue
+		}
+		if channel.ParentID == categoryId && strings.EqualFold(channel.Name, name) {
+			return channel, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// channelNameKeys are the attributes which decide whether the name of a channel collides with another one.
+func channelNameKeys(channelType string) []string {
+	if channelType == "category" {
+		return []string{"name"}
+	}
+
+	return []string{"name", "category"}
+}
+
+// customizeChannelDiff fails the plan of a channel with unique_name when its name is already taken in its category.
+// Channels which don't exist yet can't be compared with each other, only with the channels already on Discord.
+func customizeChannelDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("unique_name").(bool) || !d.NewValueKnown("server_id") || !d.NewValueKnown("name") {
+		return nil
+	}
+	channelType := d.Get("type").(string)
+	if d.Id() != "" && !d.HasChanges(append(channelNameKeys(channelType), "unique_name")...) {
+		return nil
+	}
+
+	var categoryId disgord.Snowflake
+	if channelType != "category" {
+		if !d.NewValueKnown("category") {
+			return nil
+		}
+		categoryId = getId(d.Get("category").(string))
+	}
+
+	serverId := getMajorId(d.Get("server_id"))
+	collision, err := findChannelNameCollision(m.(*Context).Client, serverId, getId(d.Id()), channelType, categoryId, d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("failed to fetch channels of server %s: %s", serverId.String(), err.Error())
+	}
+	if collision != nil {
+		return fmt.Errorf("the %s channel %s (%s) already has the name %q in this category, set unique_name to false to allow it",
+			channelType, collision.Name, collision.ID.String(), d.Get("name").(string))
+	}
+
+	return nil
+}
+
+// warnChannelNameCollision reports a duplicate name which unique_name didn't rule out, once the channel was created or renamed.
+func warnChannelNameCollision(client *disgord.Client, d *schema.ResourceData) diag.Diagnostics {
+	if d.Get("unique_name").(bool) {
+		return nil
+	}
+
+	channelType := d.Get("type").(string)
+	serverId := getMajorId(d.Get("server_id"))
+	var categoryId disgord.Snowflake
+	if channelType != "category" {
+		categoryId = getId(d.Get("category").(string))
+	}
+	collision, err := findChannelNameCollision(client, serverId, getId(d.Id()), channelType, categoryId, d.Get("name").(string))
+	if err != nil || collision == nil {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Channel %s has the same name as channel %s", d.Id(), collision.ID.String()),
+		Detail:   fmt.Sprintf("Both %s channels are named %q in the same category. Set unique_name to make this fail the plan.", channelType, collision.Name),
+	}}
+}
+
+// resourceChannelImport imports a channel by its ID, or by server_id/name among the channels of the given type.
+func resourceChannelImport(channelType string) schema.StateContextFunc {
+	return func(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+		if serverId, name, ok := parseNameImportId(data.Id()); ok {
+			channels, err := i.(*Context).Client.Guild(getId(serverId)).GetChannels()
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch channels for %s: %s", serverId, err.Error())
+			}
+
+			names := make(map[disgord.Snowflake]string)
+			for _, channel := range channels {
+				if t, ok := getTextChannelType(channel.Type); ok && t == channelType {
+					names[channel.ID] = channel.Name
+				}
+			}
+			channelId, err := findIdByName(channelType+" channel", names, name)
+			if err != nil {
+				return nil, err
+			}
+
+			data.SetId(channelId.String())
+		} else if getId(data.Id()).IsZero() {
+			return nil, fmt.Errorf("unexpected format of ID (%s), expected channel_id or server_id/name", data.Id())
+		}
+
+		return schema.ImportStatePassthroughContext(ctx, data, i)
+	}
+}
+
 func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
@@ -134,7 +344,7 @@ func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, m interf
 	)
 
 	switch channelType {
-	case "text", "news":
+	case "text", "news", "forum":
 		{
 			if v, ok := d.GetOk("topic"); ok {
 				topic = v.(string)
@@ -151,6 +361,9 @@ func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, m interf
 			if v, ok := d.GetOk("user_limit"); ok {
 				userlimit = uint(v.(int))
 			}
+			if v, ok := d.GetOk("nsfw"); ok {
+				nsfw = v.(bool)
+			}
 		}
 	}
 
@@ -170,6 +383,8 @@ func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, m interf
 		ParentID:  parentId,
 		NSFW:      nsfw,
 		Position:  d.Get("position").(int),
+		// Passing the overwrites along creates the channel locked down, instead of applying them afterwards.
+		PermissionOverwrites: getPermissionOverwrites(d.Get("permission_overwrite").(*schema.Set)),
 	})
 
 	if err != nil {
@@ -180,23 +395,236 @@ func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, m interf
 	d.Set("server_id", serverId)
 	d.Set("channel_id", channel.ID.String())
 
+	if extras, ok := getChangedChannelExtras(d, channelType); ok {
+		if err := checkRequireTag(ctx, m, channel.ID, extras); err != nil {
+			diags = append(diags, diag.Errorf("Failed to edit channel %s: %s", channel.ID.String(), err.Error())...)
+		} else if err := updateChannelExtras(ctx, m, channel.ID, extras); err != nil {
+			diags = append(diags, diag.Errorf("Failed to edit channel %s: %s", channel.ID.String(), err.Error())...)
+		}
+	}
+	if channelType == "voice" {
+		if v, ok := d.GetOk("status"); ok {
+			if err := setVoiceChannelStatus(ctx, m, channel.ID, v.(string)); err != nil {
+				diags = append(diags, diag.Errorf("Failed to set status of channel %s: %s", channel.ID.String(), err.Error())...)
+			}
+		}
+	}
+
 	if !isCategoryCh {
-		if v, ok := d.GetOk("sync_perms_with_category"); ok && v.(bool) {
-			if channel.ParentID.IsZero() {
-				return append(diags, diag.Errorf("Can't sync permissions with category. Channel (%s) doesn't have a category", channel.ID.String())...)
+		if v, ok := d.GetOk("position_within_category"); ok {
+			if position, err := updatePositionWithinCategory(ctx, m, serverId, channel.ID, v.(int)); err != nil {
+				diags = append(diags, diag.Errorf("Failed to move channel %s within its category: %s", channel.ID.String(), err.Error())...)
+			} else {
+				d.Set("position", position)
 			}
-			parent, err := client.Channel(channel.ParentID).Get()
-			if err != nil {
-				return append(diags, diag.Errorf("Can't sync permissions with category. Channel (%s) doesn't have a category", channel.ID.String())...)
+		}
+		if syncsPermsWithCategory(d) {
+			diags = append(diags, syncChannelWithCategory(ctx, client, channel)...)
+		}
+	}
+	diags = append(diags, warnChannelNameCollision(client, d)...)
+
+	return diags
+}
+
+// getChangedChannelExtras collects the changed attributes which have to be sent apart from disgord, see channelExtras.
+func getChangedChannelExtras(d *schema.ResourceData, channelType string) (*channelExtras, bool) {
+	extras := &channelExtras{}
+	edit := false
+
+	switch channelType {
+	case "text", "news", "forum":
+		{
+			if d.HasChange("default_thread_rate_limit_per_user") {
+				rateLimit := d.Get("default_thread_rate_limit_per_user").(int)
+				extras.DefaultThreadRateLimitPerUser = &rateLimit
+				edit = true
 			}
+		}
+	}
+
+	// News channels have no slowmode of their own, only their threads do.
+	if (channelType == "text" || channelType == "forum") && d.HasChange("rate_limit_per_user") {
+		rateLimit := d.Get("rate_limit_per_user").(int)
+		extras.RateLimitPerUser = &rateLimit
+		edit = true
+	}
 
-			if err = syncChannelPermissions(client, ctx, parent, channel); err != nil {
-				return append(diags, diag.Errorf("Can't sync permissions with category: %s", channel.ID.String())...)
+	if channelType == "voice" && d.HasChange("rtc_region") {
+		region := nullableString(d.Get("rtc_region").(string))
+		extras.RTCRegion = &region
+		edit = true
+	}
+
+	if channelType == "forum" {
+		if d.HasChange("default_sort_order") {
+			if sortOrder, ok := getDiscordForumSortOrder(d.Get("default_sort_order").(string)); ok {
+				extras.DefaultSortOrder = &sortOrder
+				edit = true
+			}
+		}
+		if d.HasChange("default_forum_layout") {
+			if layout, ok := getDiscordForumLayout(d.Get("default_forum_layout").(string)); ok {
+				extras.DefaultForumLayout = &layout
+				edit = true
 			}
 		}
+		if d.HasChange("default_reaction_emoji") {
+			reaction := getDefaultReaction(d)
+			extras.DefaultReactionEmoji = &reaction
+			edit = true
+		}
+		// The other flags are kept as they were read.
+		if d.HasChange("require_tag") {
+			flags := d.Get("flags").(int) &^ channelFlagRequireTag
+			if d.Get("require_tag").(bool) {
+				flags |= channelFlagRequireTag
+			}
+			extras.Flags = &flags
+			edit = true
+		}
 	}
 
-	return diags
+	return extras, edit
+}
+
+// syncsPermsWithCategory tells whether the channel takes the overwrites of its category. Syncing would replace the
+// configured permission_overwrite blocks, so they win over sync_perms_with_category, which defaults to true.
+func syncsPermsWithCategory(d *schema.ResourceData) bool {
+	_, hasOverwrites := d.GetOk("permission_overwrite")
+	return d.Get("sync_perms_with_category").(bool) && !hasOverwrites
+}
+
+// getChangedChannelCategory builds the move of a channel into another category. disgord leaves out an empty parent id,
+// which would keep the channel in its old category, so the move is sent apart from disgord.
+func getChangedChannelCategory(d *schema.ResourceData, channelType string) (*channelExtras, bool) {
+	if channelType == "category" || !d.HasChange("category") {
+		return nil, false
+	}
+
+	parentId := nullableString(d.Get("category").(string))
+	extras := &channelExtras{ParentID: &parentId}
+	if syncsPermsWithCategory(d) && parentId != "" {
+		lock := true
+		extras.LockPermissions = &lock
+	}
+
+	return extras, true
+}
+
+func setChannelExtrasData(d *schema.ResourceData, channelType string, extras *channelExtras) {
+	switch channelType {
+	case "text", "news", "forum":
+		{
+			if extras.DefaultThreadRateLimitPerUser != nil {
+				d.Set("default_thread_rate_limit_per_user", *extras.DefaultThreadRateLimitPerUser)
+			} else {
+				d.Set("default_thread_rate_limit_per_user", 0)
+			}
+			if channelType != "news" {
+				if extras.RateLimitPerUser != nil {
+					d.Set("rate_limit_per_user", *extras.RateLimitPerUser)
+				} else {
+					d.Set("rate_limit_per_user", 0)
+				}
+			}
+		}
+	case "voice":
+		{
+			if extras.Status != nil {
+				d.Set("status", *extras.Status)
+			} else {
+				d.Set("status", "")
+			}
+			if extras.RTCRegion != nil {
+				d.Set("rtc_region", string(*extras.RTCRegion))
+			} else {
+				d.Set("rtc_region", "")
+			}
+		}
+	}
+
+	if extras.LastMessageID != nil {
+		d.Set("last_message_id", *extras.LastMessageID)
+	} else {
+		d.Set("last_message_id", "")
+	}
+
+	if channelType == "forum" {
+		if extras.DefaultSortOrder != nil {
+			sortOrder, _ := getForumSortOrderName(*extras.DefaultSortOrder)
+			d.Set("default_sort_order", sortOrder)
+		}
+		if extras.DefaultForumLayout != nil {
+			layout, _ := getForumLayoutName(*extras.DefaultForumLayout)
+			d.Set("default_forum_layout", layout)
+		}
+		d.Set("default_reaction_emoji", flattenDefaultReaction(extras.DefaultReactionEmoji))
+		flags := 0
+		if extras.Flags != nil {
+			flags = *extras.Flags
+		}
+		d.Set("flags", flags)
+		d.Set("require_tag", flags&channelFlagRequireTag != 0)
+	}
+}
+
+// checkRequireTag fails turning on require_tag for a forum without tags, where nobody could create a post.
+func checkRequireTag(ctx context.Context, m interface{}, channelId disgord.Snowflake, extras *channelExtras) error {
+	if extras.Flags == nil || *extras.Flags&channelFlagRequireTag == 0 {
+		return nil
+	}
+
+	current, err := getChannelExtras(ctx, m, channelId)
+	if err != nil {
+		return err
+	}
+	if current.AvailableTags == nil || len(*current.AvailableTags) == 0 {
+		return errors.New("require_tag needs at least one available tag on the forum, add a tag first")
+	}
+
+	return nil
+}
+
+func getDefaultReaction(d *schema.ResourceData) defaultReaction {
+	var reaction defaultReaction
+	if v, ok := d.GetOk("default_reaction_emoji"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		emoji := v.([]interface{})[0].(map[string]interface{})
+		reaction.EmojiID = nullableString(emoji["emoji_id"].(string))
+		reaction.EmojiName = nullableString(emoji["emoji_name"].(string))
+	}
+
+	return reaction
+}
+
+func flattenDefaultReaction(reaction *defaultReaction) []interface{} {
+	if reaction == nil || (reaction.EmojiID == "" && reaction.EmojiName == "") {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"emoji_id":   string(reaction.EmojiID),
+		"emoji_name": string(reaction.EmojiName),
+	}}
+}
+
+// syncChannelWithCategory copies the permission overwrites of the channel's category onto the channel,
+// like "Sync Now" does in the Discord client. Channels without a category are left untouched.
+func syncChannelWithCategory(ctx context.Context, client *disgord.Client, channel *disgord.Channel) diag.Diagnostics {
+	if channel.ParentID.IsZero() {
+		return nil
+	}
+
+	parent, err := client.Channel(channel.ParentID).Get()
+	if err != nil {
+		return diag.Errorf("Can't sync permissions with category. Failed to fetch category of channel %s: %s", channel.ID.String(), err.Error())
+	}
+
+	if err = syncChannelPermissions(client, ctx, parent, channel); err != nil {
+		return diag.Errorf("Can't sync permissions with category: %s: %s", channel.ID.String(), err.Error())
+	}
+
+	return nil
 }
 
 func resourceChannelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -219,7 +647,7 @@ func resourceChannelRead(ctx context.Context, d *schema.ResourceData, m interfac
 	d.Set("position", channel.Position)
 
 	switch channelType {
-	case "text", "news":
+	case "text", "news", "forum":
 		{
 			d.Set("topic", channel.Topic)
 			d.Set("nsfw", channel.NSFW)
@@ -228,23 +656,45 @@ func resourceChannelRead(ctx context.Context, d *schema.ResourceData, m interfac
 		{
 			d.Set("bitrate", channel.Bitrate)
 			d.Set("user_limit", channel.UserLimit)
+			d.Set("nsfw", channel.NSFW)
 		}
 	}
 
 	if channelType != "category" {
-		if channel.ParentID.IsZero() {
-			d.Set("sync_perms_with_category", false)
-		} else {
-			parent, err := client.Channel(channel.ParentID).Get()
-			if err != nil {
-				return diag.Errorf("Failed to fetch category of channel %s: %s", channel.ID.String(), err.Error())
-			}
+		extras, err := getChannelExtras(ctx, m, channel.ID)
+		if err != nil {
+			return diag.Errorf("Failed to fetch channel %s: %s", channel.ID.String(), err.Error())
+		}
+		setChannelExtrasData(d, channelType, extras)
+	}
+
+	if _, ok := d.GetOk("position_within_category"); ok && channelType != "category" {
+		channels, err := client.Guild(channel.GuildID).GetChannels()
+		if err != nil {
+			return diag.Errorf("Failed to fetch channels of server %s: %s", channel.GuildID.String(), err.Error())
+		}
+		d.Set("position_within_category", getPositionWithinCategory(channels, channel))
+	}
+
+	// Channels without a category have nothing to sync with, so keep whatever is configured.
+	if channelType != "category" && !channel.ParentID.IsZero() {
+		parent, err := client.Channel(channel.ParentID).Get()
+		if err != nil {
+			return diag.Errorf("Failed to fetch category of channel %s: %s", channel.ID.String(), err.Error())
+		}
 
-			synced := arePermissionsSynced(channel, parent)
+		// Configured overwrites are never synced, see syncsPermsWithCategory, so sync_perms_with_category can't drift.
+		synced := arePermissionsSynced(channel, parent)
+		if _, ok := d.GetOk("permission_overwrite"); !ok {
 			d.Set("sync_perms_with_category", synced)
 		}
+		d.Set("permissions_synced", synced)
+	} else if channelType != "category" {
+		d.Set("permissions_synced", false)
 	}
 
+	d.Set("permission_overwrite", getConfiguredPermissionOverwritesData(d, channel))
+
 	if channel.ParentID.IsZero() {
 		d.Set("category", nil)
 	} else {
@@ -266,19 +716,22 @@ func resourceChannelUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 	var (
 		name      string
-		position  uint
+		position  *uint
 		topic     string
 		nsfw      bool
 		bitRate   uint = 64000
 		userLimit uint
-		parentId  *disgord.Snowflake
 	)
 
 	name = map[bool]string{true: d.Get("name").(string), false: channel.Name}[d.HasChange("name")]
-	position = map[bool]uint{true: uint(d.Get("position").(int)), false: uint(channel.Position)}[d.HasChange("position")]
+	// The position is only sent when it changed, so moving a channel keeps the place Discord gives it in the new category.
+	if _, ok := d.GetOk("position_within_category"); !ok && d.HasChange("position") {
+		p := uint(d.Get("position").(int))
+		position = &p
+	}
 
 	switch channelType {
-	case "text", "news":
+	case "text", "news", "forum":
 		{
 			topic = map[bool]string{true: d.Get("topic").(string), false: channel.Topic}[d.HasChange("topic")]
 			nsfw = map[bool]bool{true: d.Get("nsfw").(bool), false: channel.NSFW}[d.HasChange("nsfw")]
@@ -287,52 +740,143 @@ func resourceChannelUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		{
 			bitRate = map[bool]uint{true: uint(d.Get("bitrate").(int)), false: channel.Bitrate}[d.HasChange("bitrate")]
 			userLimit = map[bool]uint{true: uint(d.Get("user_limit").(int)), false: channel.UserLimit}[d.HasChange("user_limit")]
+			nsfw = map[bool]bool{true: d.Get("nsfw").(bool), false: channel.NSFW}[d.HasChange("nsfw")]
 		}
 	}
 
-	if channelType != "category" && d.HasChange("category") {
-		id := getId(d.Get("category").(string))
-		parentId = map[bool]*disgord.Snowflake{true: &id, false: nil}[d.Get("category").(string) != ""]
+	if move, ok := getChangedChannelCategory(d, channelType); ok {
+		if err := updateChannelExtras(ctx, m, channel.ID, move); err != nil {
+			return diag.Errorf("Failed to move channel %s to category %s: %s", d.Id(), d.Get("category").(string), err.Error())
+		}
 	}
 
 	channel, err := client.Channel(channel.ID).Update(&disgord.UpdateChannel{
 		Name:      &name,
-		Position:  &position,
+		Position:  position,
 		Topic:     &topic,
 		NSFW:      &nsfw,
 		Bitrate:   &bitRate,
 		UserLimit: &userLimit,
-		ParentID:  parentId,
 	})
 	if err != nil {
 		return diag.Errorf("Failed to update channel %s: %s", d.Id(), err.Error())
 	}
 
+	if extras, ok := getChangedChannelExtras(d, channelType); ok {
+		if err := checkRequireTag(ctx, m, channel.ID, extras); err != nil {
+			return diag.Errorf("Failed to update channel %s: %s", d.Id(), err.Error())
+		}
+		if err := updateChannelExtras(ctx, m, channel.ID, extras); err != nil {
+			return diag.Errorf("Failed to update channel %s: %s", d.Id(), err.Error())
+		}
+	}
+	if d.HasChange("permission_overwrite") {
+		if err := updatePermissionOverwrites(client, channel.ID, d); err != nil {
+			return diag.Errorf("Failed to update permission overwrites of channel %s: %s", d.Id(), err.Error())
+		}
+	}
+	if channelType == "voice" && d.HasChange("status") {
+		if err := setVoiceChannelStatus(ctx, m, channel.ID, d.Get("status").(string)); err != nil {
+			return diag.Errorf("Failed to set status of channel %s: %s", channel.ID.String(), err.Error())
+		}
+	}
+
 	if channelType != "category" {
-		if v, ok := d.GetOk("sync_perms_with_category"); ok && v.(bool) {
-			if channel.ParentID.IsZero() {
-				return append(diags, diag.Errorf("Can't sync permissions with category. Channel (%s) doesn't have a category", channel.ID.String())...)
-			}
-			parent, err := client.Channel(channel.ParentID).Get()
+		if v, ok := d.GetOk("position_within_category"); ok && d.HasChanges("position_within_category", "category") {
+			position, err := updatePositionWithinCategory(ctx, m, getMajorId(d.Get("server_id")), channel.ID, v.(int))
 			if err != nil {
-				return append(diags, diag.Errorf("Can't sync permissions with category. Channel (%s) doesn't have a category", channel.ID.String())...)
-			}
-
-			if err = syncChannelPermissions(client, ctx, parent, channel); err != nil {
-				return append(diags, diag.Errorf("Can't sync permissions with category: %s", channel.ID.String())...)
+				return diag.Errorf("Failed to move channel %s within its category: %s", d.Id(), err.Error())
 			}
+			d.Set("position", position)
 		}
+		if syncsPermsWithCategory(d) {
+			diags = append(diags, syncChannelWithCategory(ctx, client, channel)...)
+		}
+	}
+	if d.HasChanges(channelNameKeys(channelType)...) {
+		diags = append(diags, warnChannelNameCollision(client, d)...)
 	}
 
 	return diags
 }
 
+func getPermissionOverwrites(set *schema.Set) []disgord.PermissionOverwrite {
+	overwrites := make([]disgord.PermissionOverwrite, 0, set.Len())
+	for _, v := range set.List() {
+		o := v.(map[string]interface{})
+		permissionType, _ := getDiscordChannelPermissionType(o["type"].(string))
+		overwrites = append(overwrites, disgord.PermissionOverwrite{
+			ID:    getId(o["overwrite_id"].(string)),
+			Type:  disgord.PermissionOverwriteType(permissionType),
+			Allow: disgord.PermissionBit(o["allow"].(int)),
+			Deny:  disgord.PermissionBit(o["deny"].(int)),
+		})
+	}
+
+	return overwrites
+}
+
+// getConfiguredPermissionOverwritesData reads back the overwrites of the channel which are configured in permission_overwrite.
+func getConfiguredPermissionOverwritesData(d *schema.ResourceData, channel *disgord.Channel) []map[string]interface{} {
+	configured := make(map[disgord.Snowflake]bool)
+	for _, o := range getPermissionOverwrites(d.Get("permission_overwrite").(*schema.Set)) {
+		configured[o.ID] = true
+	}
+
+	overwrites := make([]map[string]interface{}, 0, len(configured))
+	for _, o := range channel.PermissionOverwrites {
+		if !configured[o.ID] {
+			continue
+		}
+		permissionType, _ := getChannelPermissionTypeName(uint(o.Type))
+		overwrites = append(overwrites, map[string]interface{}{
+			"type":         permissionType,
+			"overwrite_id": o.ID.String(),
+			"allow":        int(o.Allow),
+			"deny":         int(o.Deny),
+		})
+	}
+
+	return overwrites
+}
+
+func updatePermissionOverwrites(client *disgord.Client, channelId disgord.Snowflake, d *schema.ResourceData) error {
+	o, n := d.GetChange("permission_overwrite")
+	newOverwrites := getPermissionOverwrites(n.(*schema.Set))
+
+	kept := make(map[disgord.Snowflake]bool)
+	for _, p := range newOverwrites {
+		kept[p.ID] = true
+	}
+	for _, p := range getPermissionOverwrites(o.(*schema.Set)) {
+		if kept[p.ID] {
+			continue
+		}
+		if err := client.Channel(channelId).DeletePermission(p.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range newOverwrites {
+		if err := client.Channel(channelId).UpdatePermissions(p.ID, &disgord.UpdateChannelPermissions{
+			Allow: p.Allow,
+			Deny:  p.Deny,
+			Type:  uint(p.Type),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func resourceChannelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
 
+	// A channel which was already deleted, e.g. in the Discord client, is as good as deleted.
 	_, err := client.Channel(getId(d.Id())).Delete()
-	if err != nil {
+	if err != nil && !isDiscordError(err, discordErrorUnknownChannel) {
 		return diag.Errorf("Failed to delete channel %s: %s", d.Id(), err.Error())
 	}
 
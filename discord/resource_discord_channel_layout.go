@@ -0,0 +1,481 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+// channelPosition moves a channel in the bulk channel positions edit. Categories are sent without a parent.
+type channelPosition struct {
+	ID       string          `json:"id"`
+	Position int             `json:"position"`
+	ParentID *nullableString `json:"parent_id,omitempty"`
+}
+
+func getChannelLayoutChannelSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "text",
+				ValidateDiagFunc: func(val interface{}, path cty.Path) (diags diag.Diagnostics) {
+					if v := val.(string); !contains([]string{"text", "voice", "news", "forum"}, v) {
+						diags = append(diags, diag.Errorf("%s is not a valid type. Must be \"text\", \"voice\", \"news\" or \"forum\"", v)...)
+					}
+
+					return diags
+				},
+			},
+			"topic": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"channel_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceDiscordChannelLayout() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceChannelLayoutCreate,
+		ReadContext:   resourceChannelLayoutRead,
+		UpdateContext: resourceChannelLayoutUpdate,
+		DeleteContext: resourceChannelLayoutDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			_, err := getChannelLayoutKeys(d.Get("category").([]interface{}), d.Get("channel").([]interface{}))
+			return err
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"category": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"channel_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"channel": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     getChannelLayoutChannelSchema(),
+						},
+					},
+				},
+			},
+			"channel": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        getChannelLayoutChannelSchema(),
+				Description: "Channels outside of any category.",
+			},
+			"channel_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// getChannelLayoutKeys returns the keys of the channels in channel_ids, "category" for categories, "category/channel"
+// for their channels and "channel" for channels outside of a category. The keys must be unique to tell channels apart.
+func getChannelLayoutKeys(categories []interface{}, channels []interface{}) ([]string, error) {
+	keys := make([]string, 0)
+	add := func(key string) error {
+		for _, k := range keys {
+			if strings.EqualFold(k, key) {
+				return fmt.Errorf("%s is in the channel layout more than once, names must be unique within a category", key)
+			}
+		}
+		keys = append(keys, key)
+
+		return nil
+	}
+
+	for _, c := range categories {
+		category := c.(map[string]interface{})
+		if err := add(category["name"].(string)); err != nil {
+			return nil, err
+		}
+		for _, ch := range category["channel"].([]interface{}) {
+			if err := add(category["name"].(string) + "/" + ch.(map[string]interface{})["name"].(string)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, ch := range channels {
+		if err := add(ch.(map[string]interface{})["name"].(string)); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// channelLayout matches the configured channels with the channels of a server, and keeps track of the changes.
+type channelLayout struct {
+	client    *disgord.Client
+	serverId  disgord.Snowflake
+	channels  []*disgord.Channel
+	managed   map[disgord.Snowflake]bool
+	claimed   map[disgord.Snowflake]bool
+	positions []channelPosition
+	ids       map[string]interface{}
+}
+
+// claim finds the channel for a block: the pinned channel_id, else an unclaimed channel of the same type and name,
+// preferring the ones managed by the layout and then the ones already in the right category. Matching a channel
+// in another category moves it.
+func (l *channelLayout) claim(channelId string, channelType disgord.ChannelType, name string, parentId disgord.Snowflake) *disgord.Channel {
+	if channelId != "" {
+		if channel := findChannelById(l.channels, getId(channelId)); channel != nil {
+			l.claimed[channel.ID] = true
+			return channel
+		}
+	}
+
+	var best *disgord.Channel
+	bestScore := -1
+	for _, channel := range l.channels {
+		if l.claimed[channel.ID] || channel.Type != channelType || !strings.EqualFold(channel.Name, name) {
+			continue
+		}
+		score := 0
+		if l.managed[channel.ID] {
+			score += 2
+		}
+		if channel.ParentID == parentId {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = channel, score
+		}
+	}
+	if best != nil {
+		l.claimed[best.ID] = true
+	}
+
+	return best
+}
+
+// apply creates or edits the channel of a block and queues its position.
+func (l *channelLayout) apply(key string, block map[string]interface{}, channelType string, parentId disgord.Snowflake, position int) error {
+	channelTypeInt, _ := getDiscordChannelType(channelType)
+	name := block["name"].(string)
+	topic := ""
+	if v, ok := block["topic"]; ok {
+		topic = v.(string)
+	}
+	channelId := ""
+	if v, ok := block["channel_id"]; ok {
+		channelId = v.(string)
+	}
+
+	channel := l.claim(channelId, channelTypeInt, name, parentId)
+	if channel == nil {
+		created, err := l.client.Guild(l.serverId).CreateChannel(name, &disgord.CreateGuildChannel{
+			Type:     channelTypeInt,
+			Topic:    topic,
+			ParentID: parentId,
+			Position: position,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create channel %s: %s", key, err.Error())
+		}
+		l.claimed[created.ID] = true
+		channel = created
+	} else {
+		update := &disgord.UpdateChannel{}
+		edit := false
+		// Discord normalizes the names of text channels, so a name which only differs by that isn't a rename.
+		if channel.Name != name && !(hasNormalizedChannelName(channelType) && normalizeChannelName(channel.Name) == normalizeChannelName(name)) {
+			update.Name = &name
+			edit = true
+		}
+		if channelType != "category" && channelType != "voice" && channel.Topic != topic {
+			update.Topic = &topic
+			edit = true
+		}
+		if edit {
+			if _, err := l.client.Channel(channel.ID).Update(update); err != nil {
+				return fmt.Errorf("failed to edit channel %s (%s): %s", key, channel.ID.String(), err.Error())
+			}
+		}
+	}
+
+	p := channelPosition{ID: channel.ID.String(), Position: position}
+	if channelType != "category" {
+		parent := nullableString("")
+		if !parentId.IsZero() {
+			parent = nullableString(parentId.String())
+		}
+		p.ParentID = &parent
+	}
+	l.positions = append(l.positions, p)
+	l.ids[key] = channel.ID.String()
+
+	return nil
+}
+
+func resourceChannelLayoutCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(getId(d.Get("server_id").(string)).String())
+
+	diags = append(diags, resourceChannelLayoutUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceChannelLayoutUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+	serverId := getId(d.Id())
+
+	categories := d.Get("category").([]interface{})
+	topLevel := d.Get("channel").([]interface{})
+	if _, err := getChannelLayoutKeys(categories, topLevel); err != nil {
+		return diag.FromErr(err)
+	}
+
+	channels, err := client.Guild(serverId).GetChannels()
+	if err != nil {
+		return diag.Errorf("Failed to fetch channels of server %s: %s", serverId.String(), err.Error())
+	}
+
+	old, _ := d.GetChange("channel_ids")
+	layout := &channelLayout{
+		client:   client,
+		serverId: serverId,
+		channels: channels,
+		managed:  make(map[disgord.Snowflake]bool),
+		claimed:  make(map[disgord.Snowflake]bool),
+		ids:      make(map[string]interface{}),
+	}
+	for _, id := range old.(map[string]interface{}) {
+		layout.managed[getId(id.(string))] = true
+	}
+
+	// The channels applied before a failure are saved, so they aren't created twice. Channels of the old layout
+	// which weren't reached yet are kept under their ID, so they stay part of the layout.
+	fail := func(err error) diag.Diagnostics {
+		for _, id := range old.(map[string]interface{}) {
+			if !layout.claimed[getId(id.(string))] {
+				layout.ids[id.(string)] = id
+			}
+		}
+		d.Set("channel_ids", layout.ids)
+
+		return diag.Errorf("Failed to apply the channel layout of server %s: %s", serverId.String(), err.Error())
+	}
+
+	for i, c := range categories {
+		category := c.(map[string]interface{})
+		categoryName := category["name"].(string)
+		if err := layout.apply(categoryName, category, "category", 0, i); err != nil {
+			return fail(err)
+		}
+		categoryId := getId(layout.ids[categoryName].(string))
+
+		for j, ch := range category["channel"].([]interface{}) {
+			channel := ch.(map[string]interface{})
+			if err := layout.apply(categoryName+"/"+channel["name"].(string), channel, channel["type"].(string), categoryId, j); err != nil {
+				return fail(err)
+			}
+		}
+	}
+	for i, ch := range topLevel {
+		channel := ch.(map[string]interface{})
+		if err := layout.apply(channel["name"].(string), channel, channel["type"].(string), 0, i); err != nil {
+			return fail(err)
+		}
+	}
+
+	// All moves and positions are sent in a single edit.
+	if len(layout.positions) > 0 {
+		path := fmt.Sprintf("/guilds/%s/channels", serverId.String())
+		if err := discordRequest(ctx, m, http.MethodPatch, path, layout.positions, nil); err != nil {
+			return fail(fmt.Errorf("failed to move channels: %s", err.Error()))
+		}
+	}
+
+	// Channels which were in the layout before but no longer are deleted, channels before their categories.
+	removed := make([]*disgord.Channel, 0)
+	for id := range layout.managed {
+		if channel := findChannelById(channels, id); channel != nil && !layout.claimed[id] {
+			removed = append(removed, channel)
+		}
+	}
+	sort.SliceStable(removed, func(i, j int) bool {
+		return !isChannelCategory(removed[i]) && isChannelCategory(removed[j])
+	})
+	for _, channel := range removed {
+		if _, err := client.Channel(channel.ID).Delete(); err != nil && !isDiscordError(err, discordErrorUnknownChannel) {
+			return fail(fmt.Errorf("failed to delete channel %s: %s", channel.ID.String(), err.Error()))
+		}
+	}
+
+	d.Set("channel_ids", layout.ids)
+
+	diags = append(diags, resourceChannelLayoutRead(ctx, d, m)...)
+
+	return diags
+}
+
+// flattenChannelLayoutChannel reads back a channel block, keeping channel_id only where it was pinned.
+func flattenChannelLayoutChannel(channel *disgord.Channel, pinned map[string]bool) map[string]interface{} {
+	channelType, _ := getTextChannelType(channel.Type)
+	block := map[string]interface{}{
+		"name":       channel.Name,
+		"type":       channelType,
+		"topic":      channel.Topic,
+		"channel_id": "",
+	}
+	if pinned[channel.ID.String()] {
+		block["channel_id"] = channel.ID.String()
+	}
+
+	return block
+}
+
+func getPinnedChannelIds(d *schema.ResourceData) map[string]bool {
+	pinned := make(map[string]bool)
+	blocks := d.Get("channel").([]interface{})
+	for _, c := range d.Get("category").([]interface{}) {
+		category := c.(map[string]interface{})
+		pinned[category["channel_id"].(string)] = true
+		blocks = append(blocks, category["channel"].([]interface{})...)
+	}
+	for _, ch := range blocks {
+		pinned[ch.(map[string]interface{})["channel_id"].(string)] = true
+	}
+	delete(pinned, "")
+
+	return pinned
+}
+
+func resourceChannelLayoutRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+	serverId := getId(d.Id())
+
+	channels, err := client.Guild(serverId).GetChannels()
+	if err != nil {
+		return diag.Errorf("Failed to fetch channels of server %s: %s", serverId.String(), err.Error())
+	}
+	sort.SliceStable(channels, func(i, j int) bool {
+		return channels[i].Position < channels[j].Position
+	})
+
+	// Only the channels of the layout are read, they are put back in the tree as they are on Discord,
+	// so renames, moves and deletions made elsewhere show up as a diff.
+	managed := make([]*disgord.Channel, 0)
+	for _, id := range d.Get("channel_ids").(map[string]interface{}) {
+		if channel := findChannelById(channels, getId(id.(string))); channel != nil {
+			managed = append(managed, channel)
+		}
+	}
+	sort.SliceStable(managed, func(i, j int) bool {
+		return managed[i].Position < managed[j].Position
+	})
+
+	pinned := getPinnedChannelIds(d)
+	ids := make(map[string]interface{})
+	categories := make([]interface{}, 0)
+	topLevel := make([]interface{}, 0)
+	for _, category := range managed {
+		if !isChannelCategory(category) {
+			continue
+		}
+		ids[category.Name] = category.ID.String()
+
+		children := make([]interface{}, 0)
+		for _, channel := range managed {
+			if channel.ParentID == category.ID {
+				children = append(children, flattenChannelLayoutChannel(channel, pinned))
+				ids[category.Name+"/"+channel.Name] = channel.ID.String()
+			}
+		}
+
+		block := map[string]interface{}{"name": category.Name, "channel_id": "", "channel": children}
+		if pinned[category.ID.String()] {
+			block["channel_id"] = category.ID.String()
+		}
+		categories = append(categories, block)
+	}
+	// Channels moved into a category outside of the layout are put back at the top level.
+	for _, channel := range managed {
+		if parent := findChannelById(managed, channel.ParentID); isChannelCategory(channel) || (parent != nil && isChannelCategory(parent)) {
+			continue
+		}
+		topLevel = append(topLevel, flattenChannelLayoutChannel(channel, pinned))
+		ids[channel.Name] = channel.ID.String()
+	}
+
+	d.Set("category", categories)
+	d.Set("channel", topLevel)
+	d.Set("channel_ids", ids)
+
+	return diags
+}
+
+func isChannelCategory(channel *disgord.Channel) bool {
+	channelType, _ := getTextChannelType(channel.Type)
+
+	return channelType == "category"
+}
+
+func resourceChannelLayoutDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	channels, err := client.Guild(getId(d.Id())).GetChannels()
+	if err != nil {
+		return diag.Errorf("Failed to fetch channels of server %s: %s", d.Id(), err.Error())
+	}
+
+	// Channels are deleted before their categories, so they aren't left outside of any category on failure.
+	removed := make([]*disgord.Channel, 0)
+	for _, id := range d.Get("channel_ids").(map[string]interface{}) {
+		if channel := findChannelById(channels, getId(id.(string))); channel != nil {
+			removed = append(removed, channel)
+		}
+	}
+	sort.SliceStable(removed, func(i, j int) bool {
+		return !isChannelCategory(removed[i]) && isChannelCategory(removed[j])
+	})
+	for _, channel := range removed {
+		if _, err := client.Channel(channel.ID).Delete(); err != nil && !isDiscordError(err, discordErrorUnknownChannel) {
+			return diag.Errorf("Failed to delete channel %s: %s", channel.ID.String(), err.Error())
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,64 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestChannelLayout(t *testing.T) {
+	channels := `[
+		{"id": "10", "type": 4, "name": "Info", "position": 0},
+		{"id": "11", "type": 0, "name": "rules", "parent_id": "10", "position": 0},
+		{"id": "12", "type": 0, "name": "general", "parent_id": "10", "position": 1}
+	]`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/channels":   {{status: http.StatusOK, body: channels}},
+		"POST /guilds/1/channels":  {{status: http.StatusCreated, body: `{"id": "13", "type": 0, "name": "faq", "parent_id": "10"}`}},
+		"PATCH /channels/11":       {{status: http.StatusOK, body: `{"id": "11", "type": 0, "name": "rules", "topic": "Read me"}`}},
+		"PATCH /guilds/1/channels": {{status: http.StatusNoContent}},
+	})
+
+	r := resourceDiscordChannelLayout()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id": "1",
+		"category": []interface{}{map[string]interface{}{
+			"name": "Info",
+			"channel": []interface{}{
+				map[string]interface{}{"name": "rules", "topic": "Read me"},
+				map[string]interface{}{"name": "faq"},
+			},
+		}},
+		// Discord stores the name of a text channel in lower case, which isn't a rename.
+		"channel": []interface{}{map[string]interface{}{"name": "General"}},
+	})
+
+	if diags := resourceChannelLayoutCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	for route, expected := range map[string]int{
+		"POST /guilds/1/channels":  1,
+		"PATCH /channels/11":       1,
+		"PATCH /channels/12":       0,
+		"PATCH /guilds/1/channels": 1,
+		"DELETE /channels/12":      0,
+	} {
+		if ac := transport.count(route); ac != expected {
+			t.Errorf("%s Error: ex: %v, ac: %v", route, expected, ac)
+		}
+	}
+
+	var positions string
+	for i, route := range transport.requests {
+		if route == "PATCH /guilds/1/channels" {
+			positions = transport.bodies[i]
+		}
+	}
+	expected := `[{"id":"10","position":0},{"id":"11","position":0,"parent_id":"10"},{"id":"13","position":1,"parent_id":"10"},{"id":"12","position":0,"parent_id":null}]`
+	if positions != expected {
+		t.Errorf("positions Error: ex: %v, ac: %v", expected, positions)
+	}
+}
@@ -56,28 +56,83 @@ func resourceDiscordChannelPermission() *schema.Resource {
 				Optional:     true,
 				Type:         schema.TypeInt,
 			},
+			"partial": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only manage the bits in allow and deny, and keep the other bits of the overwrite.",
+			},
+			"resulting_allow": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"resulting_deny": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
 
+// getChannelPermissionOverwrite returns the overwrite of a role or user on a channel, nil if there is none.
+func getChannelPermissionOverwrite(client *disgord.Client, d *schema.ResourceData) (*disgord.PermissionOverwrite, error) {
+	channel, err := client.Channel(getId(d.Get("channel_id").(string))).Get()
+	if err != nil {
+		return nil, err
+	}
+
+	overwriteId := getId(d.Get("overwrite_id").(string))
+	permissionType, _ := getDiscordChannelPermissionType(d.Get("type").(string))
+	for _, x := range channel.PermissionOverwrites {
+		if uint(x.Type) == uint(permissionType) && x.ID == overwriteId {
+			return &x, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// writeChannelPermission sets the overwrite, merging the configured bits into the current ones in partial mode.
+// oldAllow and oldDeny are the bits managed until now, which are cleared unless they are still configured.
+func writeChannelPermission(client *disgord.Client, d *schema.ResourceData, oldAllow, oldDeny, allow, deny uint64) error {
+	if d.Get("partial").(bool) {
+		current, err := getChannelPermissionOverwrite(client, d)
+		if err != nil {
+			return err
+		}
+		if current != nil {
+			allow, deny = mergePermissionOverwrite(uint64(current.Allow), uint64(current.Deny), oldAllow, oldDeny, allow, deny)
+		}
+	}
+
+	return setChannelPermission(client, d, allow, deny)
+}
+
+func setChannelPermission(client *disgord.Client, d *schema.ResourceData, allow, deny uint64) error {
+	channelId := getId(d.Get("channel_id").(string))
+	overwriteId := getId(d.Get("overwrite_id").(string))
+	permissionType, _ := getDiscordChannelPermissionType(d.Get("type").(string))
+
+	return client.Channel(channelId).UpdatePermissions(overwriteId, &disgord.UpdateChannelPermissions{
+		Allow: disgord.PermissionBit(allow),
+		Deny:  disgord.PermissionBit(deny),
+		Type:  permissionType,
+	})
+}
+
 func resourceChannelPermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
 
 	channelId := getId(d.Get("channel_id").(string))
 	overwriteId := getId(d.Get("overwrite_id").(string))
-	permissionType, _ := getDiscordChannelPermissionType(d.Get("type").(string))
 
-	if err := client.Channel(channelId).UpdatePermissions(overwriteId, &disgord.UpdateChannelPermissions{
-		Allow: disgord.PermissionBit(d.Get("allow").(int)),
-		Deny:  disgord.PermissionBit(d.Get("deny").(int)),
-		Type:  permissionType,
-	}); err != nil {
+	if err := writeChannelPermission(client, d, 0, 0, uint64(d.Get("allow").(int)), uint64(d.Get("deny").(int))); err != nil {
 		return diag.Errorf("Failed to update channel permissions %s: %s", channelId.String(), err.Error())
 	} else {
 		d.SetId(strconv.Itoa(Hashcode(fmt.Sprintf("%s:%s:%s", channelId, overwriteId, d.Get("type").(string)))))
 
-		return diags
+		return append(diags, resourceChannelPermissionRead(ctx, d, m)...)
 	}
 }
 
@@ -86,21 +141,27 @@ func resourceChannelPermissionRead(ctx context.Context, d *schema.ResourceData,
 	client := m.(*Context).Client
 
 	channelId := getId(d.Get("channel_id").(string))
-	overwriteId := getId(d.Get("overwrite_id").(string))
 
-	channel, err := client.Channel(channelId).Get()
+	overwrite, err := getChannelPermissionOverwrite(client, d)
 	if err != nil {
 		return diag.Errorf("Failed to find channel %s: %s", channelId.String(), err.Error())
 	}
 
-	permissionType, _ := getDiscordChannelPermissionType(d.Get("type").(string))
-
-	for _, x := range channel.PermissionOverwrites {
-		if uint(x.Type) == uint(permissionType) && x.ID == overwriteId {
-			d.Set("allow", int(x.Allow))
-			d.Set("deny", int(x.Deny))
-			break
+	// A partial overwrite only reports the managed bits which are still set, so a lost bit shows up as a diff.
+	partial := d.Get("partial").(bool)
+	if overwrite != nil {
+		if partial {
+			d.Set("allow", d.Get("allow").(int)&int(overwrite.Allow))
+			d.Set("deny", d.Get("deny").(int)&int(overwrite.Deny))
+		} else {
+			d.Set("allow", int(overwrite.Allow))
+			d.Set("deny", int(overwrite.Deny))
 		}
+		d.Set("resulting_allow", int(overwrite.Allow))
+		d.Set("resulting_deny", int(overwrite.Deny))
+	} else if partial {
+		d.Set("allow", 0)
+		d.Set("deny", 0)
 	}
 
 	return diags
@@ -111,17 +172,13 @@ func resourceChannelPermissionUpdate(ctx context.Context, d *schema.ResourceData
 	client := m.(*Context).Client
 
 	channelId := getId(d.Get("channel_id").(string))
-	overwriteId := getId(d.Get("overwrite_id").(string))
-	permissionType, _ := getDiscordChannelPermissionType(d.Get("type").(string))
+	oldAllow, allow := d.GetChange("allow")
+	oldDeny, deny := d.GetChange("deny")
 
-	if err := client.Channel(channelId).UpdatePermissions(overwriteId, &disgord.UpdateChannelPermissions{
-		Allow: disgord.PermissionBit(d.Get("allow").(int)),
-		Deny:  disgord.PermissionBit(d.Get("deny").(int)),
-		Type:  uint(permissionType),
-	}); err != nil {
+	if err := writeChannelPermission(client, d, uint64(oldAllow.(int)), uint64(oldDeny.(int)), uint64(allow.(int)), uint64(deny.(int))); err != nil {
 		return diag.Errorf("Failed to update channel permissions %s: %s", channelId.String(), err.Error())
 	} else {
-		return diags
+		return append(diags, resourceChannelPermissionRead(ctx, d, m)...)
 	}
 }
 
@@ -132,6 +189,25 @@ func resourceChannelPermissionDelete(ctx context.Context, d *schema.ResourceData
 	channelId := getId(d.Get("channel_id").(string))
 	overwriteId := getId(d.Get("overwrite_id").(string))
 
+	// A partial overwrite only gives up its own bits, the overwrite is removed once nothing else is left in it.
+	if d.Get("partial").(bool) {
+		current, err := getChannelPermissionOverwrite(client, d)
+		if err != nil {
+			return diag.Errorf("Failed to find channel %s: %s", channelId.String(), err.Error())
+		}
+		if current == nil {
+			return diags
+		}
+		allow, deny := mergePermissionOverwrite(uint64(current.Allow), uint64(current.Deny), uint64(d.Get("allow").(int)), uint64(d.Get("deny").(int)), 0, 0)
+		if allow != 0 || deny != 0 {
+			if err := setChannelPermission(client, d, allow, deny); err != nil {
+				return diag.Errorf("Failed to update channel permissions %s: %s", channelId.String(), err.Error())
+			}
+
+			return diags
+		}
+	}
+
 	if err := client.Channel(channelId).DeletePermission(overwriteId); err != nil {
 		return diag.Errorf("Failed to delete channel permissions %s: %s", channelId.String(), err.Error())
 	} else {
@@ -0,0 +1,462 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestVoiceChannelRTCRegionRevertsToAutomatic(t *testing.T) {
+	r := resourceDiscordVoiceChannel()
+	config := map[string]interface{}{"server_id": "1", "name": "voice", "rtc_region": "us-west"}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("1")
+	config["rtc_region"] = ""
+	d = testResourceDataDiff(t, r, d.State(), config, nil)
+
+	extras, ok := getChangedChannelExtras(d, "voice")
+	if !ok {
+		t.Fatalf("edit Error: ex: %v, ac: %v", true, ok)
+	}
+	if payload, _ := json.Marshal(extras); string(payload) != `{"rtc_region":null}` {
+		t.Errorf("payload Error: ex: %v, ac: %v", `{"rtc_region":null}`, string(payload))
+	}
+
+	// Discord reports the automatic region as null, which must read back as the empty string that was configured.
+	var read channelExtras
+	if err := json.Unmarshal([]byte(`{"rtc_region":null}`), &read); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	setChannelExtrasData(d, "voice", &read)
+	if ac := d.Get("rtc_region").(string); ac != "" {
+		t.Errorf("rtc_region Error: ex: %v, ac: %v", "", ac)
+	}
+}
+
+func TestForumDefaultReactionEmoji(t *testing.T) {
+	params := []struct {
+		emoji    map[string]interface{}
+		expected string
+	}{
+		{emoji: map[string]interface{}{"emoji_name": "👍"}, expected: `{"default_reaction_emoji":{"emoji_id":null,"emoji_name":"👍"}}`},
+		{emoji: map[string]interface{}{"emoji_id": "123"}, expected: `{"default_reaction_emoji":{"emoji_id":"123","emoji_name":null}}`},
+		{emoji: nil, expected: `{"default_reaction_emoji":null}`},
+	}
+
+	for _, p := range params {
+		r := resourceDiscordForumChannel()
+		config := map[string]interface{}{
+			"server_id":              "1",
+			"name":                   "forum",
+			"default_reaction_emoji": []interface{}{map[string]interface{}{"emoji_id": "456"}},
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+		d.SetId("1")
+		delete(config, "default_reaction_emoji")
+		if p.emoji != nil {
+			config["default_reaction_emoji"] = []interface{}{p.emoji}
+		}
+		d = testResourceDataDiff(t, r, d.State(), config, nil)
+
+		extras, ok := getChangedChannelExtras(d, "forum")
+		if !ok {
+			t.Fatalf("emoji: %v - edit Error: ex: %v, ac: %v", p.emoji, true, ok)
+		}
+		if payload, _ := json.Marshal(extras); string(payload) != p.expected {
+			t.Errorf("emoji: %v - payload Error: ex: %v, ac: %v", p.emoji, p.expected, string(payload))
+		}
+
+		var read channelExtras
+		if err := json.Unmarshal([]byte(p.expected), &read); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		setChannelExtrasData(d, "forum", &read)
+		if ac := d.Get("default_reaction_emoji.#").(int); (p.emoji != nil) != (ac == 1) {
+			t.Errorf("emoji: %v - read Error: ex: %v, ac: %v", p.emoji, p.emoji, d.Get("default_reaction_emoji"))
+		}
+	}
+}
+
+func TestChannelSlowmodes(t *testing.T) {
+	params := []struct {
+		channelType string
+		changed     map[string]int
+		expected    string
+	}{
+		{channelType: "text", changed: map[string]int{"rate_limit_per_user": 30}, expected: `{"rate_limit_per_user":30}`},
+		{channelType: "text", changed: map[string]int{"default_thread_rate_limit_per_user": 600}, expected: `{"default_thread_rate_limit_per_user":600}`},
+		{channelType: "forum", changed: map[string]int{"default_thread_rate_limit_per_user": 600}, expected: `{"default_thread_rate_limit_per_user":600}`},
+		{
+			channelType: "forum",
+			changed:     map[string]int{"rate_limit_per_user": 0, "default_thread_rate_limit_per_user": 3600},
+			expected:    `{"rate_limit_per_user":0,"default_thread_rate_limit_per_user":3600}`,
+		},
+	}
+
+	for _, p := range params {
+		r := map[string]*schema.Resource{"text": resourceDiscordTextChannel(), "forum": resourceDiscordForumChannel()}[p.channelType]
+		config := map[string]interface{}{
+			"server_id":                          "1",
+			"name":                               p.channelType,
+			"rate_limit_per_user":                10,
+			"default_thread_rate_limit_per_user": 20,
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+		d.SetId("1")
+		for k, v := range p.changed {
+			config[k] = v
+		}
+		d = testResourceDataDiff(t, r, d.State(), config, nil)
+
+		extras, ok := getChangedChannelExtras(d, p.channelType)
+		if !ok {
+			t.Fatalf("type: %v - edit Error: ex: %v, ac: %v", p.channelType, true, ok)
+		}
+		if payload, _ := json.Marshal(extras); string(payload) != p.expected {
+			t.Errorf("type: %v - payload Error: ex: %v, ac: %v", p.channelType, p.expected, string(payload))
+		}
+
+		want := map[string]int{"rate_limit_per_user": 10, "default_thread_rate_limit_per_user": 20}
+		for k, v := range p.changed {
+			want[k] = v
+		}
+		rateLimit, threadRateLimit := want["rate_limit_per_user"], want["default_thread_rate_limit_per_user"]
+		read := r.Data(&terraform.InstanceState{ID: "1"})
+		setChannelExtrasData(read, p.channelType, &channelExtras{RateLimitPerUser: &rateLimit, DefaultThreadRateLimitPerUser: &threadRateLimit})
+		for k, v := range want {
+			if ac := read.Get(k).(int); ac != v {
+				t.Errorf("type: %v - %s Error: ex: %v, ac: %v", p.channelType, k, v, ac)
+			}
+		}
+	}
+}
+
+func TestForumRequireTag(t *testing.T) {
+	r := resourceDiscordForumChannel()
+	config := map[string]interface{}{"server_id": "1", "name": "forum", "require_tag": false}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("1")
+	d.Set("flags", 2)
+	config["require_tag"] = true
+	d = testResourceDataDiff(t, r, d.State(), config, nil)
+
+	extras, ok := getChangedChannelExtras(d, "forum")
+	if !ok || extras.Flags == nil || *extras.Flags != 18 {
+		t.Fatalf("flags Error: ex: %v, ac: %v", 18, extras.Flags)
+	}
+
+	params := []struct {
+		tags  string
+		fails bool
+	}{
+		{tags: `[]`, fails: true},
+		{tags: `[{"id": "5", "name": "bug"}]`, fails: false},
+	}
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /channels/1": {{status: http.StatusOK, body: fmt.Sprintf(`{"id": "1", "flags": 2, "available_tags": %s}`, p.tags)}},
+		})
+		if err := checkRequireTag(context.Background(), c, getId("1"), extras); (err != nil) != p.fails {
+			t.Errorf("tags: %v - check Error: ex: %v, ac: %v", p.tags, p.fails, err)
+		}
+	}
+
+	read := resourceDiscordForumChannel().Data(&terraform.InstanceState{ID: "1"})
+	flags := 18
+	setChannelExtrasData(read, "forum", &channelExtras{Flags: &flags})
+	if !read.Get("require_tag").(bool) || read.Get("flags").(int) != 18 {
+		t.Errorf("read Error: ex: %v, ac: %v", "require_tag with flags 18", read.State())
+	}
+}
+
+func TestChannelCategoryMove(t *testing.T) {
+	params := []struct {
+		from    string
+		to      string
+		payload string
+	}{
+		{from: "1", to: "2", payload: `{"parent_id":"2","lock_permissions":true}`},
+		{from: "", to: "2", payload: `{"parent_id":"2","lock_permissions":true}`},
+		{from: "2", to: "", payload: `{"parent_id":null}`},
+	}
+
+	for _, p := range params {
+		r := resourceDiscordTextChannel()
+		config := map[string]interface{}{"server_id": "1", "name": "text", "category": p.from}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+		d.SetId("10")
+		config["category"] = p.to
+		d = testResourceDataDiff(t, r, d.State(), config, nil)
+
+		extras, ok := getChangedChannelCategory(d, "text")
+		if !ok {
+			t.Fatalf("from: %v, to: %v - move Error: ex: %v, ac: %v", p.from, p.to, true, ok)
+		}
+		if payload, _ := json.Marshal(extras); string(payload) != p.payload {
+			t.Errorf("from: %v, to: %v - payload Error: ex: %v, ac: %v", p.from, p.to, p.payload, string(payload))
+		}
+	}
+
+	d := resourceDiscordTextChannel().Data(&terraform.InstanceState{
+		ID:         "10",
+		Attributes: map[string]string{"type": "text", "category": "1"},
+	})
+	if _, ok := getChangedChannelCategory(d, "text"); ok {
+		t.Errorf("unchanged - move Error: ex: %v, ac: %v", false, ok)
+	}
+}
+
+func TestChannelNSFWRoundTrip(t *testing.T) {
+	params := []struct {
+		channelType string
+		typeId      int
+		resource    *schema.Resource
+	}{
+		{channelType: "text", typeId: 0, resource: resourceDiscordTextChannel()},
+		{channelType: "voice", typeId: 2, resource: resourceDiscordVoiceChannel()},
+		{channelType: "forum", typeId: 15, resource: resourceDiscordForumChannel()},
+	}
+
+	for _, p := range params {
+		for _, nsfw := range []bool{true, false} {
+			channel := fmt.Sprintf(`{"id": "10", "guild_id": "1", "type": %d, "name": "channel", "nsfw": %t}`, p.typeId, nsfw)
+			c, transport := newTestContext(t, map[string][]mockResponse{
+				"GET /channels/10":   {{status: http.StatusOK, body: channel}},
+				"PATCH /channels/10": {{status: http.StatusOK, body: channel}},
+			})
+
+			d := p.resource.Data(&terraform.InstanceState{
+				ID: "10",
+				Attributes: map[string]string{
+					"server_id": "1",
+					"type":      p.channelType,
+					"name":      "channel",
+					"nsfw":      fmt.Sprintf("%t", !nsfw),
+				},
+			})
+			d.Set("nsfw", nsfw)
+
+			if diags := resourceChannelUpdate(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("type: %v, nsfw: %v - update Error: ex: %v, ac: %v", p.channelType, nsfw, nil, diags)
+			}
+
+			sent := false
+			for i, r := range transport.requests {
+				if r == "PATCH /channels/10" && strings.Contains(transport.bodies[i], fmt.Sprintf(`"nsfw":%t`, nsfw)) {
+					sent = true
+				}
+			}
+			if !sent {
+				t.Errorf("type: %v, nsfw: %v - payload Error: ex: %v, ac: %v", p.channelType, nsfw, fmt.Sprintf(`"nsfw":%t`, nsfw), transport.bodies)
+			}
+
+			d.Set("nsfw", !nsfw)
+			if diags := resourceChannelRead(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("type: %v, nsfw: %v - read Error: ex: %v, ac: %v", p.channelType, nsfw, nil, diags)
+			}
+			if ac := d.Get("nsfw").(bool); ac != nsfw {
+				t.Errorf("type: %v, nsfw: %v - nsfw Error: ex: %v, ac: %v", p.channelType, nsfw, nsfw, ac)
+			}
+		}
+	}
+}
+
+func TestChannelCreateWithPermissionOverwrites(t *testing.T) {
+	channel := `{"id": "10", "guild_id": "1", "type": 0, "name": "channel", "permission_overwrites": [
+		{"id": "1", "type": 0, "allow": "0", "deny": "1024"},
+		{"id": "3", "type": 1, "allow": "1024", "deny": "0"}
+	]}`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"POST /guilds/1/channels": {{status: http.StatusCreated, body: channel}},
+		"GET /channels/10":        {{status: http.StatusOK, body: channel}},
+		"PATCH /channels/10":      {{status: http.StatusOK, body: channel}},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceDiscordTextChannel().Schema, map[string]interface{}{
+		"server_id": "1",
+		"type":      "text",
+		"name":      "channel",
+		"permission_overwrite": []interface{}{
+			map[string]interface{}{"type": "role", "overwrite_id": "1", "deny": 1024},
+		},
+	})
+
+	if diags := resourceChannelCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	for i, r := range transport.requests {
+		if r == "POST /guilds/1/channels" && !strings.Contains(transport.bodies[i], `"permission_overwrites":[{"id":"1"`) {
+			t.Errorf("payload Error: ex: %v, ac: %v", "permission_overwrites", transport.bodies[i])
+		}
+		if strings.HasPrefix(r, "PUT /channels/10/permissions/") {
+			t.Errorf("requests Error: ex: %v, ac: %v", "no separate overwrite", r)
+		}
+	}
+
+	// The overwrite of user 3 isn't configured here, so it's left to discord_channel_permission.
+	if diags := resourceChannelRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := d.Get("permission_overwrite").(*schema.Set).Len(); ac != 1 {
+		t.Errorf("permission_overwrite Error: ex: %v, ac: %v", 1, ac)
+	}
+}
+
+func TestChannelPermissionOverwritesSkipSync(t *testing.T) {
+	channel := `{"id": "10", "guild_id": "1", "type": 0, "name": "channel", "parent_id": "5", "permission_overwrites": [
+		{"id": "1", "type": 0, "allow": "0", "deny": "1024"}
+	]}`
+	category := `{"id": "5", "guild_id": "1", "type": 4, "name": "category", "permission_overwrites": [{"id": "1", "type": 0, "allow": "1024", "deny": "0"}]}`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"POST /guilds/1/channels": {{status: http.StatusCreated, body: channel}},
+		"GET /channels/10":        {{status: http.StatusOK, body: channel}},
+		"PATCH /channels/10":      {{status: http.StatusOK, body: channel}},
+		"GET /channels/5":         {{status: http.StatusOK, body: category}},
+	})
+
+	// sync_perms_with_category defaults to true, which must not fail or undo the configured overwrites.
+	d := schema.TestResourceDataRaw(t, resourceDiscordTextChannel().Schema, map[string]interface{}{
+		"server_id": "1",
+		"type":      "text",
+		"name":      "channel",
+		"category":  "5",
+		"permission_overwrite": []interface{}{
+			map[string]interface{}{"type": "role", "overwrite_id": "1", "deny": 1024},
+		},
+	})
+
+	if diags := resourceChannelCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+	for _, r := range transport.requests {
+		if strings.Contains(r, "/channels/10/permissions/") {
+			t.Errorf("requests Error: ex: %v, ac: %v", "no sync", r)
+		}
+	}
+	if diags := resourceChannelRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := d.Get("sync_perms_with_category").(bool); !ac {
+		t.Errorf("sync_perms_with_category Error: ex: %v, ac: %v", true, ac)
+	}
+}
+
+func TestChannelUniqueName(t *testing.T) {
+	params := []struct {
+		name     string
+		category string
+		unique   bool
+		err      bool
+	}{
+		{name: "General", category: "5", unique: true, err: true},
+		{name: "General", category: "5", unique: false, err: false},
+		{name: "General", category: "6", unique: true, err: false},
+		{name: "random", category: "5", unique: true, err: false},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/channels": {{status: http.StatusOK, body: `[
+				{"id": "5", "guild_id": "1", "type": 4, "name": "Chatting"},
+				{"id": "10", "guild_id": "1", "type": 0, "name": "general", "parent_id": "5"},
+				{"id": "11", "guild_id": "1", "type": 2, "name": "random", "parent_id": "5"}
+			]`}},
+		})
+
+		r := resourceDiscordTextChannel()
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"server_id":   "1",
+			"name":        p.name,
+			"category":    p.category,
+			"unique_name": p.unique,
+		})
+		_, err := r.Diff(context.Background(), nil, config, c)
+		if (err != nil) != p.err {
+			t.Errorf("name: %v, category: %v, unique: %v - diff Error: ex: %v, ac: %v", p.name, p.category, p.unique, p.err, err)
+		}
+	}
+}
+
+func TestChannelPermissionsSynced(t *testing.T) {
+	category := `{"id": "5", "guild_id": "1", "type": 4, "name": "category", "permission_overwrites": [{"id": "1", "type": 0, "allow": "0", "deny": "1024"}]}`
+	params := []struct {
+		overwrites string
+		parentId   string
+		synced     bool
+	}{
+		{overwrites: `[{"id": "1", "type": 0, "allow": "0", "deny": "1024"}]`, parentId: `"5"`, synced: true},
+		{overwrites: `[{"id": "1", "type": 0, "allow": "1024", "deny": "0"}]`, parentId: `"5"`, synced: false},
+		{overwrites: `[]`, parentId: `null`, synced: false},
+	}
+
+	for _, p := range params {
+		channel := fmt.Sprintf(`{"id": "10", "guild_id": "1", "type": 0, "name": "channel", "parent_id": %s, "permission_overwrites": %s}`, p.parentId, p.overwrites)
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /channels/10": {{status: http.StatusOK, body: channel}},
+			"GET /channels/5":  {{status: http.StatusOK, body: category}},
+		})
+
+		d := resourceDiscordTextChannel().Data(&terraform.InstanceState{
+			ID:         "10",
+			Attributes: map[string]string{"server_id": "1", "type": "text", "name": "channel"},
+		})
+
+		if diags := resourceChannelRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("parent: %v - read Error: ex: %v, ac: %v", p.parentId, nil, diags)
+		}
+		if ac := d.Get("permissions_synced").(bool); ac != p.synced {
+			t.Errorf("overwrites: %v - permissions_synced Error: ex: %v, ac: %v", p.overwrites, p.synced, ac)
+		}
+	}
+}
+
+func TestChannelNameNormalizedPlansClean(t *testing.T) {
+	params := []struct {
+		name     string
+		stored   string
+		typeId   int
+		resource *schema.Resource
+		diff     bool
+	}{
+		{name: "General", stored: "general", typeId: 0, resource: resourceDiscordTextChannel(), diff: false},
+		{name: "My  Channel", stored: "my-channel", typeId: 0, resource: resourceDiscordTextChannel(), diff: false},
+		{name: "Release Notes", stored: "release-notes", typeId: 5, resource: resourceDiscordNewsChannel(), diff: false},
+		{name: "Ask Here", stored: "ask-here", typeId: 15, resource: resourceDiscordForumChannel(), diff: false},
+		{name: "Other Channel", stored: "my-channel", typeId: 0, resource: resourceDiscordTextChannel(), diff: true},
+		// Voice channels keep their name as it is.
+		{name: "My Channel", stored: "my channel", typeId: 2, resource: resourceDiscordVoiceChannel(), diff: true},
+	}
+
+	for _, p := range params {
+		channel := fmt.Sprintf(`{"id": "10", "guild_id": "1", "type": %d, "name": "%s", "position": 1}`, p.typeId, p.stored)
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /channels/10": {{status: http.StatusOK, body: channel}},
+		})
+
+		config := map[string]interface{}{"server_id": "1", "name": p.name}
+		d := schema.TestResourceDataRaw(t, p.resource.Schema, config)
+		d.SetId("10")
+		if diags := resourceChannelRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("name: %v - read Error: ex: %v, ac: %v", p.name, nil, diags)
+		}
+
+		diff, err := p.resource.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+		if err != nil {
+			t.Fatalf("name: %v - diff Error: ex: %v, ac: %v", p.name, nil, err)
+		}
+		ac := false
+		if diff != nil {
+			_, ac = diff.Attributes["name"]
+		}
+		if ac != p.diff {
+			t.Errorf("name: %v, stored: %v - plan Error: ex: %v, ac: %v", p.name, p.stored, p.diff, ac)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package discord
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDiscordForumChannel() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceChannelCreate,
+		ReadContext:   resourceChannelRead,
+		UpdateContext: resourceChannelUpdate,
+		DeleteContext: resourceChannelDelete,
+		CustomizeDiff: customizeChannelDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceChannelImport("forum"),
+		},
+		Schema: getChannelSchema("forum", map[string]*schema.Schema{
+			"topic": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTopicLength(4096),
+			},
+			"nsfw": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"rate_limit_per_user": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateRateLimitPerUser,
+			},
+			"default_thread_rate_limit_per_user": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateRateLimitPerUser,
+			},
+			"default_sort_order": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateDiagFunc: func(val interface{}, path cty.Path) (diags diag.Diagnostics) {
+					if _, ok := getDiscordForumSortOrder(val.(string)); !ok {
+						diags = append(diags, diag.Errorf("%s is not a valid default_sort_order. Must be \"latest_activity\" or \"creation_date\"", val.(string))...)
+					}
+
+					return diags
+				},
+			},
+			"default_forum_layout": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateDiagFunc: func(val interface{}, path cty.Path) (diags diag.Diagnostics) {
+					if _, ok := getDiscordForumLayout(val.(string)); !ok {
+						diags = append(diags, diag.Errorf("%s is not a valid default_forum_layout. Must be \"not_set\", \"list_view\" or \"gallery_view\"", val.(string))...)
+					}
+
+					return diags
+				},
+			},
+			"default_reaction_emoji": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"emoji_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"default_reaction_emoji.0.emoji_id", "default_reaction_emoji.0.emoji_name"},
+						},
+						"emoji_name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"default_reaction_emoji.0.emoji_id", "default_reaction_emoji.0.emoji_name"},
+						},
+					},
+				},
+			},
+			"require_tag": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether new posts need at least one tag. The forum must have a tag.",
+			},
+			"flags": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,157 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+// serverFeatureDiscoverable lists a server in Server Discovery.
+const serverFeatureDiscoverable = "DISCOVERABLE"
+
+type discoveryMetadata struct {
+	PrimaryCategoryID           *int      `json:"primary_category_id,omitempty"`
+	Keywords                    *[]string `json:"keywords,omitempty"`
+	EmojiDiscoverabilityEnabled *bool     `json:"emoji_discoverability_enabled,omitempty"`
+}
+
+func resourceDiscordGuildDiscovery() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGuildDiscoveryCreate,
+		ReadContext:   resourceGuildDiscoveryRead,
+		UpdateContext: resourceGuildDiscoveryUpdate,
+		DeleteContext: resourceGuildDiscoveryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGuildDiscoveryImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"primary_category_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"keywords": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 10,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+						v := val.(string)
+						if len(v) < 1 || len(v) > 30 {
+							errors = append(errors, fmt.Errorf("%s must be between 1 and 30 characters, got: %s", key, v))
+						}
+
+						return
+					},
+				},
+			},
+			"emoji_discoverability_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceGuildDiscoveryImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	data.Set("server_id", data.Id())
+
+	return schema.ImportStatePassthroughContext(ctx, data, i)
+}
+
+func getDiscoveryMetadataPath(serverId string) string {
+	return fmt.Sprintf("/guilds/%s/discovery-metadata", serverId)
+}
+
+func resourceGuildDiscoveryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(getId(d.Get("server_id").(string)).String())
+
+	diags = append(diags, resourceGuildDiscoveryUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceGuildDiscoveryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var metadata discoveryMetadata
+	if err := discordRequest(ctx, m, http.MethodGet, getDiscoveryMetadataPath(d.Id()), nil, &metadata); err != nil {
+		return diag.Errorf("Failed to fetch discovery metadata of server %s: %s", d.Id(), err.Error())
+	}
+
+	if metadata.PrimaryCategoryID != nil {
+		d.Set("primary_category_id", *metadata.PrimaryCategoryID)
+	}
+	if metadata.Keywords != nil {
+		d.Set("keywords", *metadata.Keywords)
+	} else {
+		d.Set("keywords", []string{})
+	}
+	if metadata.EmojiDiscoverabilityEnabled != nil {
+		d.Set("emoji_discoverability_enabled", *metadata.EmojiDiscoverabilityEnabled)
+	}
+
+	return diags
+}
+
+func resourceGuildDiscoveryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := getId(d.Id())
+	extras, err := getGuildExtras(ctx, m, serverId)
+	if err != nil {
+		return diag.Errorf("Failed to fetch server %s: %s", serverId.String(), err.Error())
+	}
+	if extras.Features == nil || !contains(*extras.Features, serverFeatureDiscoverable) {
+		return diag.Errorf("Server %s doesn't have the %s feature, so its discovery settings can't be edited. "+
+			"Enable Server Discovery first, e.g. with features on the server resource", serverId.String(), serverFeatureDiscoverable)
+	}
+
+	// A new resource sends every configured value, a false or empty one doesn't show up as a change.
+	isNew := d.IsNewResource()
+	metadata := &discoveryMetadata{}
+	if v, ok := d.GetOk("primary_category_id"); ok && (isNew || d.HasChange("primary_category_id")) {
+		categoryId := v.(int)
+		metadata.PrimaryCategoryID = &categoryId
+	}
+	if isNew || d.HasChange("keywords") {
+		keywords := make([]string, 0)
+		for _, k := range d.Get("keywords").([]interface{}) {
+			keywords = append(keywords, k.(string))
+		}
+		metadata.Keywords = &keywords
+	}
+	if isNew || d.HasChange("emoji_discoverability_enabled") {
+		enabled := d.Get("emoji_discoverability_enabled").(bool)
+		metadata.EmojiDiscoverabilityEnabled = &enabled
+	}
+
+	if err := discordRequest(ctx, m, http.MethodPatch, getDiscoveryMetadataPath(serverId.String()), metadata, nil); err != nil {
+		return diag.Errorf("Failed to edit discovery metadata of server %s: %s", serverId.String(), err.Error())
+	}
+
+	diags = append(diags, resourceGuildDiscoveryRead(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceGuildDiscoveryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Discovery metadata can't be removed, it's left as it is until the server leaves Server Discovery.
+
+	return diags
+}
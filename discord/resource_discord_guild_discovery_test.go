@@ -0,0 +1,56 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGuildDiscovery(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1":                      {{status: http.StatusOK, body: `{"id": "1", "features": ["DISCOVERABLE"]}`}},
+		"PATCH /guilds/1/discovery-metadata": {{status: http.StatusOK, body: `{}`}},
+		"GET /guilds/1/discovery-metadata": {{
+			status: http.StatusOK,
+			body:   `{"primary_category_id": 6, "keywords": ["terraform"], "emoji_discoverability_enabled": false}`,
+		}},
+	})
+
+	r := resourceDiscordGuildDiscovery()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id":                     "1",
+		"primary_category_id":           6,
+		"keywords":                      []interface{}{"terraform"},
+		"emoji_discoverability_enabled": false,
+	})
+	d.MarkNewResource()
+
+	if diags := resourceGuildDiscoveryCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	expected := `{"primary_category_id":6,"keywords":["terraform"],"emoji_discoverability_enabled":false}`
+	var ac string
+	for i, route := range transport.requests {
+		if route == "PATCH /guilds/1/discovery-metadata" {
+			ac = transport.bodies[i]
+		}
+	}
+	if ac != expected {
+		t.Errorf("payload Error: ex: %v, ac: %v", expected, ac)
+	}
+	if ac := d.Get("keywords").([]interface{}); len(ac) != 1 || ac[0] != "terraform" {
+		t.Errorf("keywords Error: ex: %v, ac: %v", []string{"terraform"}, ac)
+	}
+
+	c, _ = newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1": {{status: http.StatusOK, body: `{"id": "1", "features": []}`}},
+	})
+	diags := resourceGuildDiscoveryUpdate(context.Background(), d, c)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "DISCOVERABLE") {
+		t.Errorf("feature check Error: ex: %v, ac: %v", "an error naming DISCOVERABLE", diags)
+	}
+}
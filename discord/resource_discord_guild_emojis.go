@@ -0,0 +1,498 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+type guildEmoji struct {
+	ID       string    `json:"id,omitempty"`
+	Name     string    `json:"name"`
+	Image    string    `json:"image,omitempty"`
+	Roles    *[]string `json:"roles,omitempty"`
+	Animated bool      `json:"animated,omitempty"`
+	Managed  bool      `json:"managed,omitempty"`
+}
+
+// emojiFileExtensions are the image files picked up from an emoji directory.
+var emojiFileExtensions = []string{".png", ".jpg", ".jpeg", ".gif"}
+
+// emojiRename moves an uploaded emoji to the name of its image file.
+type emojiRename struct {
+	id   string
+	from string
+	to   string
+}
+
+var emojiNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{2,32}$`)
+
+func resourceDiscordGuildEmojis() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGuildEmojisCreate,
+		ReadContext:   resourceGuildEmojisRead,
+		UpdateContext: resourceGuildEmojisUpdate,
+		DeleteContext: resourceGuildEmojisDelete,
+		CustomizeDiff: customizeGuildEmojisDiff,
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"emojis": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"emojis", "directory"},
+			},
+			"directory": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"emojis", "directory"},
+			},
+			"exclusive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Discord can only restrict emojis to roles, there is no restriction to channels.
+			"role_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the only roles allowed to use the emojis, everyone may use them when empty.",
+			},
+			"emoji_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"image_hashes": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// getEmojiFiles returns the image file of each emoji, keyed by emoji name. The files of a directory are named after
+// their emoji, e.g. party_parrot.gif.
+func getEmojiFiles(emojis map[string]interface{}, directory string) (map[string]string, error) {
+	files := make(map[string]string)
+	for name, path := range emojis {
+		files[name] = path.(string)
+	}
+
+	if directory != "" {
+		entries, err := os.ReadDir(directory)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if entry.IsDir() || !contains(emojiFileExtensions, ext) {
+				continue
+			}
+			name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+			if other, ok := files[name]; ok {
+				return nil, fmt.Errorf("emoji %s is given by both %s and %s", name, filepath.Base(other), entry.Name())
+			}
+			files[name] = filepath.Join(directory, entry.Name())
+		}
+	}
+
+	for name := range files {
+		if !emojiNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("emoji name %s must be 2 to 32 letters, digits or underscores", name)
+		}
+	}
+
+	return files, nil
+}
+
+// getEmojiImages reads the configured emoji files as data URIs, keyed by emoji name.
+func getEmojiImages(d interface{ Get(string) interface{} }) (map[string]string, error) {
+	files, err := getEmojiFiles(d.Get("emojis").(map[string]interface{}), d.Get("directory").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string]string, len(files))
+	for name, path := range files {
+		image, err := getImageFileDataURI(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read emoji %s from %s: %s", name, path, err.Error())
+		}
+		images[name] = image
+	}
+
+	return images, nil
+}
+
+// getEmojiRoles returns the roles the emojis are restricted to, an empty list lets everyone use them.
+func getEmojiRoles(d *schema.ResourceData) *[]string {
+	roles := make([]string, 0)
+	for _, id := range d.Get("role_ids").(*schema.Set).List() {
+		roles = append(roles, id.(string))
+	}
+	sort.Strings(roles)
+
+	return &roles
+}
+
+// hasEmojiRoles reports whether an emoji is restricted to exactly the given roles.
+func hasEmojiRoles(emoji *guildEmoji, roles []string) bool {
+	current := make([]string, 0)
+	if emoji.Roles != nil {
+		current = *emoji.Roles
+	}
+	if len(current) != len(roles) {
+		return false
+	}
+	for _, id := range current {
+		if !contains(roles, id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getEmojiImageHash(image string) string {
+	return strconv.Itoa(Hashcode(image))
+}
+
+// customizeGuildEmojisDiff plans an update whenever the emoji files differ from the uploaded ones, as the paths alone
+// don't change when the files are edited.
+func customizeGuildEmojisDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if serverId := d.Get("server_id").(string); serverId != "" && d.Get("role_ids").(*schema.Set).Contains(serverId) {
+		return fmt.Errorf("role_ids can't contain @everyone (the server ID), leave role_ids empty to let everyone use the emojis")
+	}
+
+	if !d.NewValueKnown("emojis") || !d.NewValueKnown("directory") {
+		return nil
+	}
+
+	images, err := getEmojiImages(d)
+	if err != nil {
+		return err
+	}
+
+	hashes := make(map[string]interface{}, len(images))
+	for name, image := range images {
+		hashes[name] = getEmojiImageHash(image)
+	}
+
+	current := d.Get("image_hashes").(map[string]interface{})
+	if len(current) == len(hashes) {
+		changed := false
+		for name, hash := range hashes {
+			if current[name] != hash {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+
+	return d.SetNew("image_hashes", hashes)
+}
+
+// getServerEmojis fetches the emojis of a server, keyed by ID.
+func getServerEmojis(ctx context.Context, m interface{}, serverId string) (map[string]*guildEmoji, error) {
+	var emojis []*guildEmoji
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s/emojis", serverId), nil, &emojis); err != nil {
+		return nil, err
+	}
+
+	byId := make(map[string]*guildEmoji, len(emojis))
+	for _, emoji := range emojis {
+		byId[emoji.ID] = emoji
+	}
+
+	return byId, nil
+}
+
+// checkEmojiSlots fails when the server would end up with more static or animated emojis than its boost tier allows.
+func checkEmojiSlots(ctx context.Context, m interface{}, serverId string, static int, animated int) error {
+	extras, err := getGuildExtras(ctx, m, getId(serverId))
+	if err != nil {
+		return fmt.Errorf("failed to fetch server %s: %s", serverId, err.Error())
+	}
+
+	premiumTier := 0
+	if extras.PremiumTier != nil {
+		premiumTier = *extras.PremiumTier
+	}
+	features := make([]string, 0)
+	if extras.Features != nil {
+		features = *extras.Features
+	}
+	limit, _ := getServerAssetLimits(premiumTier, features)
+
+	if static > limit {
+		return fmt.Errorf("server %s has %d static emoji slots at boost tier %d, but %d static emojis are needed", serverId, limit, premiumTier, static)
+	}
+	if animated > limit {
+		return fmt.Errorf("server %s has %d animated emoji slots at boost tier %d, but %d animated emojis are needed", serverId, limit, premiumTier, animated)
+	}
+
+	return nil
+}
+
+func resourceGuildEmojisCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(d.Get("server_id").(string))
+
+	diags = append(diags, resourceGuildEmojisUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceGuildEmojisRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	current, err := getServerEmojis(ctx, m, serverId)
+	if err != nil {
+		return diag.Errorf("Failed to fetch emojis of server %s: %s", serverId, err.Error())
+	}
+
+	// Emojis deleted or renamed outside of Terraform are dropped, so they are uploaded again.
+	hashes := d.Get("image_hashes").(map[string]interface{})
+	ids := make(map[string]interface{})
+	newHashes := make(map[string]interface{})
+	tracked := make(map[string]bool)
+	for name, id := range d.Get("emoji_ids").(map[string]interface{}) {
+		if emoji, ok := current[id.(string)]; ok && emoji.Name == name {
+			ids[name] = id
+			newHashes[name] = hashes[name]
+			tracked[id.(string)] = true
+		}
+	}
+
+	// In exclusive mode the other emojis are tracked without a hash, so the next plan deletes them.
+	if d.Get("exclusive").(bool) {
+		for id, emoji := range current {
+			if _, ok := ids[emoji.Name]; ok || tracked[id] || emoji.Managed {
+				continue
+			}
+			ids[emoji.Name] = id
+			newHashes[emoji.Name] = ""
+		}
+	}
+
+	d.Set("emoji_ids", ids)
+	d.Set("image_hashes", newHashes)
+
+	// An emoji restricted to other roles is reported through role_ids, so the next plan restricts it again.
+	roles := *getEmojiRoles(d)
+	for id := range tracked {
+		if !hasEmojiRoles(current[id], roles) {
+			restricted := make([]string, 0)
+			if current[id].Roles != nil {
+				restricted = *current[id].Roles
+			}
+			d.Set("role_ids", restricted)
+			break
+		}
+	}
+
+	return diags
+}
+
+func resourceGuildEmojisUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	serverId := d.Get("server_id").(string)
+	images, err := getEmojiImages(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := getServerEmojis(ctx, m, serverId)
+	if err != nil {
+		return diag.Errorf("Failed to fetch emojis of server %s: %s", serverId, err.Error())
+	}
+
+	// ids and hashes start from the emojis uploaded before and follow every change, so a failed apply keeps track of
+	// what was done.
+	oldHashes, _ := d.GetChange("image_hashes")
+	ids := make(map[string]string)
+	hashes := make(map[string]string)
+	for name, id := range d.Get("emoji_ids").(map[string]interface{}) {
+		if _, ok := current[id.(string)]; ok {
+			ids[name] = id.(string)
+			hashes[name] = fmt.Sprint(oldHashes.(map[string]interface{})[name])
+		}
+	}
+	save := func() {
+		d.Set("emoji_ids", ids)
+		d.Set("image_hashes", hashes)
+	}
+
+	// Emojis whose image is unchanged are kept, and a renamed file renames its emoji instead of uploading it again.
+	kept := make(map[string]bool)
+	unused := make(map[string]string)
+	for name, id := range ids {
+		if image, ok := images[name]; ok && getEmojiImageHash(image) == hashes[name] {
+			kept[name] = true
+		} else {
+			unused[name] = id
+		}
+	}
+	names := make([]string, 0, len(images))
+	for name := range images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	renames := make([]emojiRename, 0)
+	toCreate := make([]string, 0)
+	for _, name := range names {
+		if kept[name] {
+			continue
+		}
+		hash := getEmojiImageHash(images[name])
+		renamed := false
+		for oldName, id := range unused {
+			if oldName != name && hashes[oldName] == hash {
+				renames = append(renames, emojiRename{id: id, from: oldName, to: name})
+				delete(unused, oldName)
+				renamed = true
+				break
+			}
+		}
+		if !renamed {
+			toCreate = append(toCreate, name)
+		}
+	}
+
+	toDelete := make(map[string]bool)
+	for _, id := range unused {
+		toDelete[id] = true
+	}
+	if d.Get("exclusive").(bool) {
+		trackedIds := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			trackedIds[id] = true
+		}
+		for id, emoji := range current {
+			if !emoji.Managed && !trackedIds[id] {
+				toDelete[id] = true
+			}
+		}
+	}
+
+	static, animated := 0, 0
+	for id, emoji := range current {
+		if toDelete[id] {
+			continue
+		}
+		if emoji.Animated {
+			animated++
+		} else {
+			static++
+		}
+	}
+	for _, name := range toCreate {
+		if strings.HasPrefix(images[name], "data:image/gif") {
+			animated++
+		} else {
+			static++
+		}
+	}
+	if err := checkEmojiSlots(ctx, m, serverId, static, animated); err != nil {
+		return diag.FromErr(err)
+	}
+
+	roles := getEmojiRoles(d)
+	for name := range kept {
+		emoji := current[ids[name]]
+		if hasEmojiRoles(emoji, *roles) {
+			continue
+		}
+		path := fmt.Sprintf("/guilds/%s/emojis/%s", serverId, emoji.ID)
+		if err := discordRequest(ctx, m, http.MethodPatch, path, &guildEmoji{Name: name, Roles: roles}, nil); err != nil {
+			save()
+			return diag.Errorf("Failed to restrict emoji %s to roles: %s", name, err.Error())
+		}
+	}
+
+	for id := range toDelete {
+		if err := deleteEmoji(ctx, m, serverId, id); err != nil {
+			save()
+			return diag.Errorf("Failed to delete emoji %s of server %s: %s", id, serverId, err.Error())
+		}
+		for name, trackedId := range ids {
+			if trackedId == id {
+				delete(ids, name)
+				delete(hashes, name)
+			}
+		}
+	}
+
+	for _, rename := range renames {
+		path := fmt.Sprintf("/guilds/%s/emojis/%s", serverId, rename.id)
+		if err := discordRequest(ctx, m, http.MethodPatch, path, &guildEmoji{Name: rename.to, Roles: roles}, nil); err != nil {
+			save()
+			return diag.Errorf("Failed to rename emoji %s to %s: %s", rename.from, rename.to, err.Error())
+		}
+		// Another emoji may have been renamed to the old name already.
+		if ids[rename.from] == rename.id {
+			delete(ids, rename.from)
+			delete(hashes, rename.from)
+		}
+		ids[rename.to] = rename.id
+		hashes[rename.to] = getEmojiImageHash(images[rename.to])
+	}
+
+	for _, name := range toCreate {
+		var emoji guildEmoji
+		path := fmt.Sprintf("/guilds/%s/emojis", serverId)
+		if err := discordRequest(ctx, m, http.MethodPost, path, &guildEmoji{Name: name, Image: images[name], Roles: roles}, &emoji); err != nil {
+			save()
+			return diag.Errorf("Failed to upload emoji %s to server %s: %s", name, serverId, err.Error())
+		}
+		ids[name] = emoji.ID
+		hashes[name] = getEmojiImageHash(images[name])
+	}
+
+	save()
+
+	return nil
+}
+
+func deleteEmoji(ctx context.Context, m interface{}, serverId string, emojiId string) error {
+	err := discordRequest(ctx, m, http.MethodDelete, fmt.Sprintf("/guilds/%s/emojis/%s", serverId, emojiId), nil, nil)
+	if isDiscordError(err, discordErrorUnknownEmoji) {
+		return nil
+	}
+
+	return err
+}
+
+func resourceGuildEmojisDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	for name, id := range d.Get("emoji_ids").(map[string]interface{}) {
+		if err := deleteEmoji(ctx, m, serverId, id.(string)); err != nil {
+			return diag.Errorf("Failed to delete emoji %s of server %s: %s", name, serverId, err.Error())
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,164 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func writeEmojiFile(t *testing.T, dir string, name string, data string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return path
+}
+
+func TestGuildEmojisDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeEmojiFile(t, dir, "wave.png", "\x89PNG\r\n\x1a\nwave")
+	writeEmojiFile(t, dir, "party.gif", "GIF89aparty")
+	writeEmojiFile(t, dir, "README.md", "emojis")
+
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/emojis":      {{status: http.StatusOK, body: `[{"id": "9", "name": "old"}, {"id": "8", "name": "twitch", "managed": true}]`}},
+		"GET /guilds/1":             {{status: http.StatusOK, body: `{"id": "1", "premium_tier": 0, "features": []}`}},
+		"DELETE /guilds/1/emojis/9": {{status: http.StatusNoContent}},
+		"POST /guilds/1/emojis": {
+			{status: http.StatusCreated, body: `{"id": "10", "name": "party", "animated": true}`},
+			{status: http.StatusCreated, body: `{"id": "11", "name": "wave"}`},
+		},
+	})
+
+	r := resourceDiscordGuildEmojis()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id": "1",
+		"directory": dir,
+		"exclusive": true,
+	})
+
+	if diags := resourceGuildEmojisCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	if ac := transport.count("POST /guilds/1/emojis"); ac != 2 {
+		t.Errorf("upload Error: ex: %v, ac: %v", 2, ac)
+	}
+	if ac := transport.count("DELETE /guilds/1/emojis/9"); ac != 1 {
+		t.Errorf("exclusive Error: ex: %v, ac: %v", 1, ac)
+	}
+	if ac := transport.count("DELETE /guilds/1/emojis/8"); ac != 0 {
+		t.Errorf("managed Error: ex: %v, ac: %v", 0, ac)
+	}
+	ids := d.Get("emoji_ids").(map[string]interface{})
+	if ids["party"] != "10" || ids["wave"] != "11" {
+		t.Errorf("emoji_ids Error: ex: %v, ac: %v", "party=10 wave=11", ids)
+	}
+}
+
+func TestGuildEmojisRename(t *testing.T) {
+	dir := t.TempDir()
+	path := writeEmojiFile(t, dir, "hello.png", "\x89PNG\r\n\x1a\nwave")
+	image, _ := getImageFileDataURI(path)
+
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/emojis":      {{status: http.StatusOK, body: `[{"id": "11", "name": "wave"}]`}},
+		"GET /guilds/1":             {{status: http.StatusOK, body: `{"id": "1", "premium_tier": 0, "features": []}`}},
+		"PATCH /guilds/1/emojis/11": {{status: http.StatusOK, body: `{"id": "11", "name": "hello"}`}},
+	})
+
+	r := resourceDiscordGuildEmojis()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id": "1",
+		"emojis":    map[string]interface{}{"wave": path},
+	})
+	d.SetId("1")
+	d.Set("emoji_ids", map[string]interface{}{"wave": "11"})
+	d.Set("image_hashes", map[string]interface{}{"wave": getEmojiImageHash(image)})
+
+	d = r.Data(d.State())
+	d.Set("emojis", map[string]interface{}{"hello": path})
+
+	if diags := resourceGuildEmojisUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("update Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	if ac := transport.count("POST /guilds/1/emojis"); ac != 0 {
+		t.Errorf("upload Error: ex: %v, ac: %v", 0, ac)
+	}
+	for i, req := range transport.requests {
+		if req == "PATCH /guilds/1/emojis/11" && !strings.Contains(transport.bodies[i], `"name":"hello"`) {
+			t.Errorf("rename Error: ex: %v, ac: %v", "hello", transport.bodies[i])
+		}
+	}
+	if ac := d.Get("emoji_ids").(map[string]interface{})["hello"]; ac != "11" {
+		t.Errorf("emoji_ids Error: ex: %v, ac: %v", "11", ac)
+	}
+}
+
+func TestGuildEmojisSlotLimit(t *testing.T) {
+	c, _ := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1": {{status: http.StatusOK, body: `{"id": "1", "premium_tier": 1, "features": []}`}},
+	})
+
+	if err := checkEmojiSlots(context.Background(), c, "1", 100, 3); err != nil {
+		t.Errorf("within limit Error: ex: %v, ac: %v", nil, err)
+	}
+	if err := checkEmojiSlots(context.Background(), c, "1", 2, 101); err == nil || !strings.Contains(err.Error(), "100 animated emoji slots") {
+		t.Errorf("over limit Error: ex: %v, ac: %v", "100 animated emoji slots", err)
+	}
+}
+
+func TestGuildEmojisRoles(t *testing.T) {
+	dir := t.TempDir()
+	path := writeEmojiFile(t, dir, "wave.png", "\x89PNG\r\n\x1a\nwave")
+	image, _ := getImageFileDataURI(path)
+
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/emojis":      {{status: http.StatusOK, body: `[{"id": "11", "name": "wave", "roles": []}]`}},
+		"GET /guilds/1":             {{status: http.StatusOK, body: `{"id": "1", "premium_tier": 0, "features": []}`}},
+		"PATCH /guilds/1/emojis/11": {{status: http.StatusOK, body: `{"id": "11", "name": "wave", "roles": ["5"]}`}},
+	})
+
+	r := resourceDiscordGuildEmojis()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id": "1",
+		"emojis":    map[string]interface{}{"wave": path},
+		"role_ids":  []interface{}{"5"},
+	})
+	d.SetId("1")
+	d.Set("emoji_ids", map[string]interface{}{"wave": "11"})
+	d.Set("image_hashes", map[string]interface{}{"wave": getEmojiImageHash(image)})
+	d = r.Data(d.State())
+
+	// Read reports the unrestricted emoji, so the plan restricts it again.
+	if diags := resourceGuildEmojisRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := d.Get("role_ids").(*schema.Set).Len(); ac != 0 {
+		t.Errorf("read role_ids Error: ex: %v, ac: %v", 0, ac)
+	}
+
+	d.Set("role_ids", []interface{}{"5"})
+	if diags := resourceGuildEmojisUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("update Error: ex: %v, ac: %v", nil, diags)
+	}
+	sent := false
+	for i, req := range transport.requests {
+		if req == "PATCH /guilds/1/emojis/11" && strings.Contains(transport.bodies[i], `"roles":["5"]`) {
+			sent = true
+		}
+	}
+	if !sent {
+		t.Errorf("payload Error: ex: %v, ac: %v", `"roles":["5"]`, transport.bodies)
+	}
+	if ac := transport.count("POST /guilds/1/emojis"); ac != 0 {
+		t.Errorf("upload Error: ex: %v, ac: %v", 0, ac)
+	}
+}
@@ -0,0 +1,130 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+type guildPrune struct {
+	Days              int      `json:"days"`
+	ComputePruneCount bool     `json:"compute_prune_count"`
+	IncludeRoles      []string `json:"include_roles,omitempty"`
+}
+
+type guildPruneResult struct {
+	Pruned *int `json:"pruned"`
+}
+
+func resourceDiscordGuildPrune() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGuildPruneCreate,
+		ReadContext:   resourceGuildPruneRead,
+		DeleteContext: resourceGuildPruneDelete,
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"days": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  7,
+				ForceNew: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					v := val.(int)
+					if v < 1 || v > 30 {
+						errors = append(errors, fmt.Errorf("days must be between 1 and 30 inclusive, got: %d", v))
+					}
+
+					return
+				},
+			},
+			"include_roles": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				ForceNew: true,
+				Set:      schema.HashString,
+			},
+			"compute_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"pruned": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGuildPruneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := getId(d.Get("server_id").(string))
+	days := d.Get("days").(int)
+
+	includeRoles := make([]string, 0)
+	for _, r := range d.Get("include_roles").(*schema.Set).List() {
+		includeRoles = append(includeRoles, r.(string))
+	}
+
+	var result guildPruneResult
+	if d.Get("compute_only").(bool) {
+		query := url.Values{}
+		query.Set("days", strconv.Itoa(days))
+		if len(includeRoles) > 0 {
+			query.Set("include_roles", strings.Join(includeRoles, ","))
+		}
+
+		path := fmt.Sprintf("/guilds/%s/prune?%s", serverId.String(), query.Encode())
+		if err := discordRequest(ctx, m, http.MethodGet, path, nil, &result); err != nil {
+			return diag.Errorf("Failed to fetch prune count for server %s: %s", serverId.String(), err.Error())
+		}
+	} else {
+		path := fmt.Sprintf("/guilds/%s/prune", serverId.String())
+		if err := discordRequest(ctx, m, http.MethodPost, path, &guildPrune{
+			Days:              days,
+			ComputePruneCount: true,
+			IncludeRoles:      includeRoles,
+		}, &result); err != nil {
+			return diag.Errorf("Failed to prune members of server %s: %s", serverId.String(), err.Error())
+		}
+	}
+
+	// Every run is its own prune, the time it ran tells it apart from the other prunes of the server.
+	d.SetId(generateTwoPartId(serverId.String(), strconv.FormatInt(time.Now().UnixNano(), 10)))
+	if result.Pruned != nil {
+		d.Set("pruned", *result.Pruned)
+	}
+
+	return diags
+}
+
+func resourceGuildPruneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// A prune is a one-shot action, there is nothing to read back from Discord.
+
+	return diags
+}
+
+func resourceGuildPruneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// noop
+
+	return diags
+}
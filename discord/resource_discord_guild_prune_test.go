@@ -0,0 +1,54 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGuildPrune(t *testing.T) {
+	params := []struct {
+		computeOnly bool
+		route       string
+		query       string
+		body        string
+	}{
+		{computeOnly: true, route: "GET /guilds/1/prune", query: "days=30&include_roles=5"},
+		{computeOnly: false, route: "POST /guilds/1/prune", body: `{"days":30,"compute_prune_count":true,"include_roles":["5"]}`},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			p.route: {{status: http.StatusOK, body: `{"pruned": 4}`}},
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceDiscordGuildPrune().Schema, map[string]interface{}{
+			"server_id":     "1",
+			"days":          30,
+			"include_roles": []interface{}{"5"},
+			"compute_only":  p.computeOnly,
+		})
+		if diags := resourceGuildPruneCreate(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("compute_only: %v - create Error: ex: %v, ac: %v", p.computeOnly, nil, diags)
+		}
+
+		if len(transport.requests) != 1 || transport.requests[0] != p.route {
+			t.Fatalf("compute_only: %v - requests Error: ex: %v, ac: %v", p.computeOnly, []string{p.route}, transport.requests)
+		}
+		if ac := transport.queries[0]; ac != p.query {
+			t.Errorf("compute_only: %v - query Error: ex: %v, ac: %v", p.computeOnly, p.query, ac)
+		}
+		if ac := transport.bodies[0]; ac != p.body {
+			t.Errorf("compute_only: %v - payload Error: ex: %v, ac: %v", p.computeOnly, p.body, ac)
+		}
+		if ac := d.Get("pruned").(int); ac != 4 {
+			t.Errorf("compute_only: %v - pruned Error: ex: %v, ac: %v", p.computeOnly, 4, ac)
+		}
+		if ac := d.Id(); !strings.HasPrefix(ac, "1:") {
+			t.Errorf("compute_only: %v - id Error: ex: %v, ac: %v", p.computeOnly, "1:<time of the prune>", ac)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+// memberVoiceState holds the voice fields of a server member.
+// See: https://discord.com/developers/docs/resources/guild#modify-guild-member
+type memberVoiceState struct {
+	ChannelID *string `json:"channel_id,omitempty"`
+	Mute      *bool   `json:"mute,omitempty"`
+	Deaf      *bool   `json:"deaf,omitempty"`
+}
+
+func resourceDiscordGuildVoiceState() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGuildVoiceStateCreate,
+		ReadContext:   resourceGuildVoiceStateRead,
+		UpdateContext: resourceGuildVoiceStateUpdate,
+		DeleteContext: resourceGuildVoiceStateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGuildVoiceStateImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"channel_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"mute": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"deaf": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceGuildVoiceStateImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	if serverId, userId, err := getBothIds(data.Id()); err != nil {
+		return nil, err
+	} else {
+		data.Set("server_id", serverId.String())
+		data.Set("user_id", userId.String())
+
+		return schema.ImportStatePassthroughContext(ctx, data, i)
+	}
+}
+
+// updateMemberVoiceState edits the voice fields of a member. Discord refuses them while the member isn't in a voice channel,
+// which is reported as a warning since the overrides can only be applied once the member connects.
+func updateMemberVoiceState(ctx context.Context, m interface{}, serverId string, userId string, state *memberVoiceState) diag.Diagnostics {
+	path := fmt.Sprintf("/guilds/%s/members/%s", serverId, userId)
+	if err := discordRequest(ctx, m, http.MethodPatch, path, state, nil); err != nil {
+		return getMemberVoiceStateDiagnostics(serverId, userId, err)
+	}
+
+	return nil
+}
+
+// getMemberVoiceStateDiagnostics turns the error of a voice state edit into a warning while the user isn't in voice.
+func getMemberVoiceStateDiagnostics(serverId string, userId string, err error) diag.Diagnostics {
+	if isDiscordError(err, discordErrorNotConnectedToVoice) {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "User " + userId + " is not connected to voice in server " + serverId,
+			Detail:   "The voice state is only applied while the user is in a voice channel, apply again once they are.",
+		}}
+	}
+
+	return diag.Errorf("Failed to edit voice state of member %s: %s", userId, err.Error())
+}
+
+func resourceGuildVoiceStateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	userId := d.Get("user_id").(string)
+
+	d.SetId(generateTwoPartId(serverId, userId))
+
+	diags = append(diags, resourceGuildVoiceStateUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceGuildVoiceStateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	userId := d.Get("user_id").(string)
+
+	var member memberVoiceState
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s/members/%s", serverId, userId), nil, &member); err != nil {
+		if isDiscordError(err, discordErrorUnknownMember) {
+			d.SetId("")
+			return diags
+		}
+
+		return diag.Errorf("Failed to fetch member %s: %s", userId, err.Error())
+	}
+	if member.Mute != nil {
+		d.Set("mute", *member.Mute)
+	}
+	if member.Deaf != nil {
+		d.Set("deaf", *member.Deaf)
+	}
+
+	// A member who isn't connected is in no channel, so a configured channel plans the move again until it's applied.
+	var voiceState memberVoiceState
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s/voice-states/%s", serverId, userId), nil, &voiceState); err != nil {
+		if !isDiscordError(err, discordErrorUnknownVoiceState) {
+			return diag.Errorf("Failed to fetch voice state of member %s: %s", userId, err.Error())
+		}
+		d.Set("channel_id", "")
+	} else if _, ok := d.GetOk("channel_id"); ok && voiceState.ChannelID != nil {
+		d.Set("channel_id", *voiceState.ChannelID)
+	}
+
+	return diags
+}
+
+func resourceGuildVoiceStateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	userId := d.Get("user_id").(string)
+
+	mute := d.Get("mute").(bool)
+	deaf := d.Get("deaf").(bool)
+	state := &memberVoiceState{Mute: &mute, Deaf: &deaf}
+	if v, ok := d.GetOk("channel_id"); ok && d.HasChange("channel_id") {
+		channelId := v.(string)
+		state.ChannelID = &channelId
+	}
+
+	diags = append(diags, updateMemberVoiceState(ctx, m, serverId, userId, state)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags = append(diags, resourceGuildVoiceStateRead(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceGuildVoiceStateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	userId := d.Get("user_id").(string)
+
+	// The member isn't moved back, only the server mute and deafen are lifted. A member who left the server has
+	// nothing left to lift.
+	unset := false
+	path := fmt.Sprintf("/guilds/%s/members/%s", serverId, userId)
+	if err := discordRequest(ctx, m, http.MethodPatch, path, &memberVoiceState{Mute: &unset, Deaf: &unset}, nil); err != nil {
+		if isDiscordError(err, discordErrorUnknownMember) {
+			return diags
+		}
+		diags = append(diags, getMemberVoiceStateDiagnostics(serverId, userId, err)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,95 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestGuildVoiceState(t *testing.T) {
+	params := []struct {
+		connected bool
+		warnings  int
+	}{
+		{connected: true, warnings: 0},
+		{connected: false, warnings: 1},
+	}
+
+	for _, p := range params {
+		routes := map[string][]mockResponse{
+			"GET /guilds/1/members/2":      {{status: http.StatusOK, body: `{"user": {"id": "2"}, "mute": true, "deaf": false}`}},
+			"PATCH /guilds/1/members/2":    {{status: http.StatusOK, body: `{"user": {"id": "2"}}`}},
+			"GET /guilds/1/voice-states/2": {{status: http.StatusOK, body: `{"user_id": "2", "channel_id": "5"}`}},
+		}
+		if !p.connected {
+			routes["PATCH /guilds/1/members/2"] = []mockResponse{{status: http.StatusBadRequest, body: `{"code": 40032, "message": "Target user is not connected to voice."}`}}
+			routes["GET /guilds/1/voice-states/2"] = []mockResponse{{status: http.StatusNotFound, body: `{"code": 10065, "message": "Unknown Voice State"}`}}
+		}
+		c, transport := newTestContext(t, routes)
+
+		r := resourceDiscordGuildVoiceState()
+		config := map[string]interface{}{
+			"server_id":  "1",
+			"user_id":    "2",
+			"channel_id": "5",
+			"mute":       true,
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+		diags := resourceGuildVoiceStateCreate(context.Background(), d, c)
+		if diags.HasError() {
+			t.Fatalf("connected: %v - create Error: ex: %v, ac: %v", p.connected, nil, diags)
+		}
+		warnings := 0
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Warning {
+				warnings++
+			}
+		}
+		if warnings != p.warnings {
+			t.Errorf("connected: %v - warnings Error: ex: %v, ac: %v", p.connected, p.warnings, diags)
+		}
+
+		body := ""
+		for i, req := range transport.requests {
+			if req == "PATCH /guilds/1/members/2" {
+				body = transport.bodies[i]
+			}
+		}
+		if !strings.Contains(body, `"channel_id":"5"`) || !strings.Contains(body, `"mute":true`) {
+			t.Errorf("connected: %v - payload Error: ex: %v, ac: %v", p.connected, `{"channel_id":"5","mute":true,"deaf":false}`, body)
+		}
+		// Without a voice state the move wasn't applied, so it's planned again.
+		expected := map[bool]string{true: "5", false: ""}[p.connected]
+		if ac := d.Get("channel_id").(string); ac != expected {
+			t.Errorf("connected: %v - channel_id Error: ex: %v, ac: %v", p.connected, expected, ac)
+		}
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+		if err != nil {
+			t.Fatalf("connected: %v - diff Error: ex: %v, ac: %v", p.connected, nil, err)
+		}
+		if ac := diff != nil && diff.Attributes["channel_id"] != nil; ac == p.connected {
+			t.Errorf("connected: %v - plan Error: ex: %v, ac: %v", p.connected, !p.connected, diff)
+		}
+	}
+}
+
+func TestGuildVoiceStateDeleteUnknownMember(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"PATCH /guilds/1/members/2": {{status: http.StatusNotFound, body: `{"code": 10007, "message": "Unknown Member"}`}},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceDiscordGuildVoiceState().Schema, map[string]interface{}{"server_id": "1", "user_id": "2"})
+	d.SetId("1:2")
+	if diags := resourceGuildVoiceStateDelete(context.Background(), d, c); len(diags) > 0 {
+		t.Errorf("delete Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := transport.count("PATCH /guilds/1/members/2"); ac != 1 {
+		t.Errorf("requests Error: ex: %v, ac: %v", 1, ac)
+	}
+}
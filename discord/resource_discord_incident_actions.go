@@ -0,0 +1,208 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+// incidentActionsMaxDuration is the longest pause Discord accepts.
+const incidentActionsMaxDuration = 24 * time.Hour
+
+// incidentActions pauses the invites and direct messages of a server until the given times.
+// See: https://discord.com/developers/docs/resources/guild#modify-guild-incident-actions
+type incidentActions struct {
+	InvitesDisabledUntil nullableString `json:"invites_disabled_until"`
+	DMsDisabledUntil     nullableString `json:"dms_disabled_until"`
+}
+
+func resourceDiscordIncidentActions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIncidentActionsCreate,
+		ReadContext:   resourceIncidentActionsRead,
+		UpdateContext: resourceIncidentActionsUpdate,
+		DeleteContext: resourceIncidentActionsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIncidentActionsImport,
+		},
+		CustomizeDiff: customizeIncidentActionsDiff,
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"invites_disabled_until": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateTimestamp,
+				DiffSuppressFunc: suppressEqualTimestamps,
+			},
+			"dms_disabled_until": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateTimestamp,
+				DiffSuppressFunc: suppressEqualTimestamps,
+			},
+		},
+	}
+}
+
+func validateTimestamp(val interface{}, key string) (warns []string, errors []error) {
+	v := val.(string)
+	if v == "" {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, v); err != nil {
+		errors = append(errors, fmt.Errorf("%s must be an RFC 3339 timestamp, got: %s", key, v))
+	}
+
+	return
+}
+
+// validateIncidentTimestamp checks that a pause ends in the future, and no later than Discord allows. It's only checked
+// when the timestamp changes, a configured pause which ran out mustn't fail every later plan.
+func validateIncidentTimestamp(key string, v string) error {
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return fmt.Errorf("%s must be an RFC 3339 timestamp, got: %s", key, v)
+	}
+	if now := time.Now(); !t.After(now) {
+		return fmt.Errorf("%s must be in the future, got: %s", key, v)
+	} else if t.After(now.Add(incidentActionsMaxDuration)) {
+		return fmt.Errorf("%s must be at most %s from now, got: %s", key, incidentActionsMaxDuration, v)
+	}
+
+	return nil
+}
+
+func customizeIncidentActionsDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range []string{"invites_disabled_until", "dms_disabled_until"} {
+		// HasChange doesn't take suppressEqualTimestamps into account, the planned attributes do.
+		if len(d.GetChangedKeysPrefix(key)) == 0 || !d.NewValueKnown(key) {
+			continue
+		}
+		if v := d.Get(key).(string); v != "" {
+			if err := validateIncidentTimestamp(key, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// suppressEqualTimestamps ignores differences in how Discord formats a timestamp, e.g. fractional seconds. A configured
+// pause which ran out is read back as no pause, which isn't a difference either.
+func suppressEqualTimestamps(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" && d.Id() != "" && getActiveIncidentTimestamp(nullableString(new)) == "" {
+		return true
+	}
+
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
+
+// getActiveIncidentTimestamp returns the timestamp as long as the pause it ends is still active.
+func getActiveIncidentTimestamp(v nullableString) string {
+	t, err := time.Parse(time.RFC3339, string(v))
+	if err != nil || !t.After(time.Now()) {
+		return ""
+	}
+
+	return string(v)
+}
+
+func resourceIncidentActionsImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	data.Set("server_id", data.Id())
+
+	return schema.ImportStatePassthroughContext(ctx, data, i)
+}
+
+func putIncidentActions(ctx context.Context, m interface{}, serverId string, actions *incidentActions) error {
+	return discordRequest(ctx, m, http.MethodPut, fmt.Sprintf("/guilds/%s/incident-actions", serverId), actions, nil)
+}
+
+func resourceIncidentActionsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := getId(d.Get("server_id").(string))
+	if err := putIncidentActions(ctx, m, serverId.String(), &incidentActions{
+		InvitesDisabledUntil: nullableString(d.Get("invites_disabled_until").(string)),
+		DMsDisabledUntil:     nullableString(d.Get("dms_disabled_until").(string)),
+	}); err != nil {
+		return diag.Errorf("Failed to pause invites and DMs of server %s: %s", serverId.String(), err.Error())
+	}
+
+	d.SetId(serverId.String())
+
+	diags = append(diags, resourceIncidentActionsRead(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceIncidentActionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := getId(d.Id())
+	extras, err := getGuildExtras(ctx, m, serverId)
+	if err != nil {
+		if isDiscordError(err, discordErrorUnknownGuild) {
+			d.SetId("")
+			return diags
+		}
+
+		return diag.Errorf("Failed to fetch server %s: %s", serverId.String(), err.Error())
+	}
+
+	actions := &incidentActions{}
+	if extras.IncidentsData != nil {
+		actions = extras.IncidentsData
+	}
+
+	// A pause which has run out is the same as no pause at all.
+	d.Set("invites_disabled_until", getActiveIncidentTimestamp(actions.InvitesDisabledUntil))
+	d.Set("dms_disabled_until", getActiveIncidentTimestamp(actions.DMsDisabledUntil))
+
+	return diags
+}
+
+func resourceIncidentActionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Discord replaces both pauses on every call, so the unchanged one is sent again.
+	if err := putIncidentActions(ctx, m, d.Id(), &incidentActions{
+		InvitesDisabledUntil: nullableString(d.Get("invites_disabled_until").(string)),
+		DMsDisabledUntil:     nullableString(d.Get("dms_disabled_until").(string)),
+	}); err != nil {
+		return diag.Errorf("Failed to pause invites and DMs of server %s: %s", d.Id(), err.Error())
+	}
+
+	diags = append(diags, resourceIncidentActionsRead(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceIncidentActionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := putIncidentActions(ctx, m, d.Id(), &incidentActions{}); err != nil {
+		return diag.Errorf("Failed to resume invites and DMs of server %s: %s", d.Id(), err.Error())
+	}
+
+	return diags
+}
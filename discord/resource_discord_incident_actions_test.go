@@ -0,0 +1,75 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/net/context"
+)
+
+func TestIncidentActions(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	past := time.Now().Add(-time.Hour).UTC()
+	guild := fmt.Sprintf(
+		`{"id": "1", "incidents_data": {"invites_disabled_until": %q, "dms_disabled_until": %q}}`,
+		future.Format("2006-01-02T15:04:05.000000+00:00"), past.Format(time.RFC3339),
+	)
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1":                  {{status: http.StatusOK, body: guild}},
+		"PUT /guilds/1/incident-actions": {{status: http.StatusOK, body: `{}`}},
+	})
+
+	r := resourceDiscordIncidentActions()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id":              "1",
+		"invites_disabled_until": future.Format(time.RFC3339),
+	})
+
+	if diags := resourceIncidentActionsCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	expected := fmt.Sprintf(`{"invites_disabled_until":%q,"dms_disabled_until":null}`, future.Format(time.RFC3339))
+	if len(transport.bodies) == 0 || transport.bodies[0] != expected {
+		t.Errorf("payload Error: ex: %v, ac: %v", expected, transport.bodies)
+	}
+
+	if ac, _ := time.Parse(time.RFC3339, d.Get("invites_disabled_until").(string)); !ac.Equal(future) {
+		t.Errorf("invites_disabled_until Error: ex: %v, ac: %v", future, d.Get("invites_disabled_until"))
+	}
+	if ac := d.Get("dms_disabled_until").(string); ac != "" {
+		t.Errorf("dms_disabled_until Error: ex: %v, ac: %v", "", ac)
+	}
+
+	params := []struct {
+		until time.Time
+		fails bool
+	}{
+		{until: future, fails: false},
+		{until: past, fails: true},
+		{until: time.Now().Add(25 * time.Hour), fails: true},
+	}
+	for _, p := range params {
+		if err := validateIncidentTimestamp("dms_disabled_until", p.until.Format(time.RFC3339)); (err != nil) != p.fails {
+			t.Errorf("until: %v - validation Error: ex: %v, ac: %v", p.until, p.fails, err)
+		}
+	}
+
+	// Once the pause ran out it's read back as no pause, which mustn't fail the plan of the unchanged configuration.
+	config := map[string]interface{}{"server_id": "1", "invites_disabled_until": past.Format(time.RFC3339)}
+	state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{"id": "1", "server_id": "1", "invites_disabled_until": ""}}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatalf("diff Error: ex: %v, ac: %v", nil, err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("plan Error: ex: %v, ac: %v", "no changes", diff.Attributes)
+	}
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), c); err == nil {
+		t.Errorf("new plan Error: ex: %v, ac: %v", "an error for a past timestamp", err)
+	}
+}
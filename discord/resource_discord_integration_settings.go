@@ -0,0 +1,219 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+type integration struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Type              string `json:"type"`
+	ExpireBehavior    *int   `json:"expire_behavior,omitempty"`
+	ExpireGracePeriod *int   `json:"expire_grace_period,omitempty"`
+	EnableEmoticons   *bool  `json:"enable_emoticons,omitempty"`
+}
+
+func resourceDiscordIntegrationSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIntegrationSettingsCreate,
+		ReadContext:   resourceIntegrationSettingsRead,
+		UpdateContext: resourceIntegrationSettingsUpdate,
+		DeleteContext: resourceIntegrationSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIntegrationSettingsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"integration_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"expire_behavior": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					v := val.(int)
+					if v != 0 && v != 1 {
+						errors = append(errors, fmt.Errorf("expire_behavior must be 0 (remove role) or 1 (kick), got: %d", v))
+					}
+
+					return
+				},
+			},
+			"expire_grace_period": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					v := val.(int)
+					expected := []int{1, 3, 7, 14, 30}
+					if !contains(expected, v) {
+						errors = append(errors, fmt.Errorf("expire_grace_period must be set to one of the following values: %d, but got: %d", expected, v))
+					}
+
+					return
+				},
+			},
+			"enable_emoticons": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"detach_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceIntegrationSettingsImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	if serverId, integrationId, err := getBothIds(data.Id()); err != nil {
+		return nil, err
+	} else {
+		data.Set("server_id", serverId.String())
+		data.Set("integration_id", integrationId.String())
+
+		return schema.ImportStatePassthroughContext(ctx, data, i)
+	}
+}
+
+func findIntegration(ctx context.Context, m interface{}, serverId string, integrationId string) (*integration, error) {
+	var integrations []*integration
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s/integrations", serverId), nil, &integrations); err != nil {
+		return nil, err
+	}
+
+	for _, i := range integrations {
+		if i.ID == integrationId {
+			return i, nil
+		}
+	}
+
+	// Reported like Discord reports an unknown integration, so callers handle both the same way.
+	return nil, &discordAPIError{StatusCode: http.StatusNotFound, Code: discordErrorUnknownIntegration, Message: "Unknown Integration"}
+}
+
+func resourceIntegrationSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	integrationId := d.Get("integration_id").(string)
+
+	if _, err := findIntegration(ctx, m, serverId, integrationId); err != nil {
+		return diag.Errorf("Failed to fetch integration %s: %s", integrationId, err.Error())
+	}
+
+	d.SetId(generateTwoPartId(serverId, integrationId))
+
+	diags = append(diags, resourceIntegrationSettingsUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceIntegrationSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	integrationId := d.Get("integration_id").(string)
+
+	i, err := findIntegration(ctx, m, serverId, integrationId)
+	if err != nil {
+		if isDiscordError(err, discordErrorUnknownIntegration) {
+			d.SetId("")
+			return diags
+		}
+
+		return diag.Errorf("Failed to fetch integration %s: %s", integrationId, err.Error())
+	}
+
+	d.Set("name", i.Name)
+	d.Set("type", i.Type)
+	if i.ExpireBehavior != nil {
+		d.Set("expire_behavior", *i.ExpireBehavior)
+	}
+	if i.ExpireGracePeriod != nil {
+		d.Set("expire_grace_period", *i.ExpireGracePeriod)
+	}
+	if i.EnableEmoticons != nil {
+		d.Set("enable_emoticons", *i.EnableEmoticons)
+	}
+
+	return diags
+}
+
+func resourceIntegrationSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	integrationId := d.Get("integration_id").(string)
+
+	params := &integration{}
+	edit := false
+
+	if d.HasChange("expire_behavior") {
+		expireBehavior := d.Get("expire_behavior").(int)
+		params.ExpireBehavior = &expireBehavior
+		edit = true
+	}
+	if d.HasChange("expire_grace_period") {
+		expireGracePeriod := d.Get("expire_grace_period").(int)
+		params.ExpireGracePeriod = &expireGracePeriod
+		edit = true
+	}
+	if d.HasChange("enable_emoticons") {
+		enableEmoticons := d.Get("enable_emoticons").(bool)
+		params.EnableEmoticons = &enableEmoticons
+		edit = true
+	}
+
+	if edit {
+		path := fmt.Sprintf("/guilds/%s/integrations/%s", serverId, integrationId)
+		if err := discordRequest(ctx, m, http.MethodPatch, path, params, nil); err != nil {
+			return diag.Errorf("Failed to edit integration %s: %s", integrationId, err.Error())
+		}
+	}
+
+	diags = append(diags, resourceIntegrationSettingsRead(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceIntegrationSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !d.Get("detach_on_destroy").(bool) {
+		return diags
+	}
+
+	serverId := d.Get("server_id").(string)
+	integrationId := d.Get("integration_id").(string)
+
+	path := fmt.Sprintf("/guilds/%s/integrations/%s", serverId, integrationId)
+	if err := discordRequest(ctx, m, http.MethodDelete, path, nil, nil); err != nil {
+		return diag.Errorf("Failed to detach integration %s: %s", integrationId, err.Error())
+	}
+
+	return diags
+}
@@ -0,0 +1,38 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIntegrationSettingsReadRemoved(t *testing.T) {
+	params := []struct {
+		integrations string
+		id           string
+	}{
+		{integrations: `[{"id": "2", "name": "twitch", "type": "twitch"}]`, id: "1:2"},
+		{integrations: `[]`, id: ""},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/integrations": {{status: http.StatusOK, body: p.integrations}},
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceDiscordIntegrationSettings().Schema, map[string]interface{}{
+			"server_id":      "1",
+			"integration_id": "2",
+		})
+		d.SetId("1:2")
+
+		if diags := resourceIntegrationSettingsRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("integrations: %v - read Error: ex: %v, ac: %v", p.integrations, nil, diags)
+		}
+		if ac := d.Id(); ac != p.id {
+			t.Errorf("integrations: %v - id Error: ex: %v, ac: %v", p.integrations, p.id, ac)
+		}
+	}
+}
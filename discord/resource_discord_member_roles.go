@@ -2,7 +2,9 @@ package discord
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"net/http"
 
 	"github.com/andersfylling/disgord"
 	"github.com/andersfylling/snowflake/v5"
@@ -62,6 +64,23 @@ func resourceDiscordMemberRoles() *schema.Resource {
 					},
 				},
 			},
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Remove every role of the member which isn't in a role block with has_role.",
+			},
+			// Computed so that the flag is left as it is when it isn't configured.
+			"bypass_verification": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the member may talk without meeting the verification level of the server.",
+			},
+			"flags": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -80,15 +99,18 @@ func resourceMemberRolesCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	d.SetId(generateTwoPartId(serverId.String(), userId.String()))
 
-	diags = append(diags, resourceMemberRolesRead(ctx, d, m)...)
+	// The roles are read back after the edit, reading them first would replace the configured roles.
 	diags = append(diags, resourceMemberRolesUpdate(ctx, d, m)...)
+	if diags.HasError() {
+		return diags
+	}
+	diags = append(diags, resourceMemberRolesRead(ctx, d, m)...)
 
 	return diags
 }
 
 func resourceMemberRolesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*Context).Client
 
 	// parse server ID and userID out of the ID:
 	var serverId, userId snowflake.Snowflake
@@ -102,7 +124,7 @@ func resourceMemberRolesRead(ctx context.Context, d *schema.ResourceData, m inte
 		userId = getId(uId)
 	}
 
-	member, err := client.Guild(serverId).Member(userId).Get()
+	member, err := getServerMember(ctx, m, serverId, userId)
 	if err != nil {
 		return diag.Errorf("Could not get member %s in %s: %s", userId.String(), serverId.String(), err.Error())
 	}
@@ -113,31 +135,77 @@ func resourceMemberRolesRead(ctx context.Context, d *schema.ResourceData, m inte
 	for _, r := range items {
 		v, _ := convertToRoleSchema(r)
 
-		if hasRole(member, v.RoleId) {
+		if hasRole(&member.Member, v.RoleId) {
 			roles = append(roles, &RoleSchema{RoleId: v.RoleId, HasRole: true})
 		} else {
 			roles = append(roles, &RoleSchema{RoleId: v.RoleId, HasRole: false})
 		}
 	}
 
+	// In exclusive mode the roles which aren't configured show up as a diff, so the next apply removes them.
+	if d.Get("exclusive").(bool) {
+		for _, roleId := range member.Roles {
+			if wasRemoved(items, &RoleSchema{RoleId: roleId}) {
+				roles = append(roles, &RoleSchema{RoleId: roleId, HasRole: true})
+			}
+		}
+	}
+
+	roleData := make([]map[string]interface{}, 0, len(roles))
+	for _, r := range roles {
+		roleData = append(roleData, map[string]interface{}{"role_id": r.RoleId.String(), "has_role": r.HasRole})
+	}
+	d.Set("role", roleData)
+	d.Set("flags", member.Flags)
+	d.Set("bypass_verification", member.Flags&memberFlagBypassesVerification != 0)
+
 	return diags
 }
 
 func resourceMemberRolesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*Context).Client
 
 	serverId := getId(d.Get("server_id").(string))
 	userId := getId(d.Get("user_id").(string))
 
-	member, err := client.Guild(serverId).Member(userId).Get()
+	member, err := getServerMember(ctx, m, serverId, userId)
 	if err != nil {
 		return diag.Errorf("Could not get member %s in %s: %s", userId.String(), serverId.String(), err.Error())
 	}
 
 	old, new := d.GetChange("role")
-	oldItems := old.(*schema.Set).List()
-	items := new.(*schema.Set).List()
+	roles := getMemberRoles(&member.Member, old.(*schema.Set).List(), new.(*schema.Set).List(), d.Get("exclusive").(bool))
+
+	// All roles are sent in a single edit, which is atomic and faster than adding and removing them one at a time.
+	edit := &memberEdit{Roles: &roles}
+	// A configured bypass_verification is applied to a new resource even when it's false, which isn't a change.
+	bypass := d.GetRawConfig().GetAttr("bypass_verification")
+	if !bypass.IsNull() && (d.IsNewResource() || d.HasChange("bypass_verification")) {
+		flags := setMemberFlag(member.Flags, memberFlagBypassesVerification, d.Get("bypass_verification").(bool))
+		edit.Flags = &flags
+	}
+	path := fmt.Sprintf("/guilds/%s/members/%s", serverId.String(), userId.String())
+	if err := discordRequest(ctx, m, http.MethodPatch, path, edit, nil); err != nil {
+		return diag.Errorf("Failed to edit member %s: %s", userId.String(), err.Error())
+	}
+
+	return diags
+}
+
+// getMemberRoles returns the roles of a member after applying the role blocks. Roles which aren't configured are kept,
+// unless exclusive is set which leaves exactly the roles with has_role.
+func getMemberRoles(member *disgord.Member, oldItems []interface{}, items []interface{}, exclusive bool) []disgord.Snowflake {
+	if exclusive {
+		roles := make([]disgord.Snowflake, 0, len(items))
+		for _, r := range items {
+			v, _ := convertToRoleSchema(r)
+			if v.HasRole {
+				roles = append(roles, v.RoleId)
+			}
+		}
+
+		return roles
+	}
 
 	roles := member.Roles
 
@@ -166,13 +234,7 @@ func resourceMemberRolesUpdate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
-	if _, err := client.Guild(serverId).Member(userId).Update(&disgord.UpdateMember{
-		Roles: &roles,
-	}); err != nil {
-		return diag.Errorf("Failed to edit member %s: %s", userId.String(), err.Error())
-	}
-
-	return diags
+	return roles
 }
 
 func wasRemoved(items []interface{}, v *RoleSchema) bool {
@@ -194,6 +256,10 @@ func resourceMemberRolesDelete(ctx context.Context, d *schema.ResourceData, m in
 
 	member, err := client.Guild(serverId).Member(userId).Get()
 	if err != nil {
+		// The member left the server, so there are no roles left to remove.
+		if isDiscordError(err, discordErrorUnknownMember) {
+			return diags
+		}
 		return diag.Errorf("Could not get member %s in %s: %s", userId.String(), serverId.String(), err.Error())
 	}
 
@@ -0,0 +1,223 @@
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+func resourceDiscordMemberRolesBulk() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMemberRolesBulkCreate,
+		ReadContext:   resourceMemberRolesBulkRead,
+		UpdateContext: resourceMemberRolesBulkUpdate,
+		DeleteContext: resourceMemberRolesBulkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMemberRolesBulkImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_ids": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				AtLeastOneOf:  []string{"user_ids", "all_members"},
+				ConflictsWith: []string{"all_members"},
+			},
+			"all_members": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Grant the role to every current member of the server. Members who join later aren't covered.",
+				ConflictsWith: []string{"exclusive"},
+			},
+			"exclusive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceMemberRolesBulkImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	if serverId, roleId, err := getBothIds(data.Id()); err != nil {
+		return nil, err
+	} else {
+		data.Set("server_id", serverId.String())
+		data.Set("role_id", roleId.String())
+
+		return schema.ImportStatePassthroughContext(ctx, data, i)
+	}
+}
+
+func getMembersWithRole(members []*disgord.Member, roleId disgord.Snowflake) map[string]bool {
+	withRole := make(map[string]bool)
+	for _, member := range members {
+		if hasRole(member, roleId) {
+			withRole[member.User.ID.String()] = true
+		}
+	}
+
+	return withRole
+}
+
+func resourceMemberRolesBulkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serverId := d.Get("server_id").(string)
+	roleId := d.Get("role_id").(string)
+
+	d.SetId(generateTwoPartId(serverId, roleId))
+
+	diags = append(diags, resourceMemberRolesBulkUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceMemberRolesBulkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	serverId := getId(d.Get("server_id").(string))
+	roleId := getId(d.Get("role_id").(string))
+
+	members, err := client.Guild(serverId).GetMembers(&disgord.GetMembers{Limit: 0})
+	if err != nil {
+		return diag.Errorf("Failed to fetch members for %s: %s", serverId.String(), err.Error())
+	}
+	withRole := getMembersWithRole(members, roleId)
+	inServer := make(map[string]bool, len(members))
+	for _, member := range members {
+		inServer[member.User.ID.String()] = true
+	}
+
+	// A member without the role turns all_members into a diff, so the next apply grants it to them.
+	if d.Get("all_members").(bool) {
+		d.Set("all_members", len(withRole) == len(members))
+	}
+
+	// Users who aren't members can't have the role, they are kept so the plan doesn't try to add them on every run.
+	// The apply warns about them instead.
+	exclusive := d.Get("exclusive").(bool)
+	userIds := make([]string, 0, len(withRole))
+	if exclusive {
+		for userId := range withRole {
+			userIds = append(userIds, userId)
+		}
+	}
+	for _, u := range d.Get("user_ids").(*schema.Set).List() {
+		userId := u.(string)
+		if !inServer[userId] || (withRole[userId] && !exclusive) {
+			userIds = append(userIds, userId)
+		}
+	}
+
+	d.Set("user_ids", userIds)
+
+	return diags
+}
+
+func resourceMemberRolesBulkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	serverId := getId(d.Get("server_id").(string))
+	roleId := getId(d.Get("role_id").(string))
+	exclusive := d.Get("exclusive").(bool)
+
+	members, err := client.Guild(serverId).GetMembers(&disgord.GetMembers{Limit: 0})
+	if err != nil {
+		return diag.Errorf("Failed to fetch members for %s: %s", serverId.String(), err.Error())
+	}
+	withRole := getMembersWithRole(members, roleId)
+	inServer := make(map[string]bool, len(members))
+	for _, member := range members {
+		inServer[member.User.ID.String()] = true
+	}
+
+	old, new := d.GetChange("user_ids")
+	wanted := new.(*schema.Set)
+	if d.Get("all_members").(bool) {
+		wanted = schema.NewSet(schema.HashString, nil)
+		for userId := range inServer {
+			wanted.Add(userId)
+		}
+	}
+
+	for _, u := range wanted.List() {
+		userId := u.(string)
+		if !inServer[userId] {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "User " + userId + " is not a member of server " + serverId.String(),
+			})
+			continue
+		}
+		if withRole[userId] {
+			continue
+		}
+		if err := client.Guild(serverId).Member(getId(userId)).AddRole(roleId); err != nil {
+			return append(diags, diag.Errorf("Failed to add role %s to member %s: %s", roleId.String(), userId, err.Error())...)
+		}
+	}
+
+	// Members removed from the list lose the role, and in exclusive mode so does everyone else not in the list.
+	toRemove := old.(*schema.Set).Difference(wanted).List()
+	if exclusive {
+		for userId := range withRole {
+			toRemove = append(toRemove, userId)
+		}
+	}
+	removed := make(map[string]bool)
+	for _, u := range toRemove {
+		userId := u.(string)
+		if wanted.Contains(userId) || !withRole[userId] || removed[userId] {
+			continue
+		}
+		if err := client.Guild(serverId).Member(getId(userId)).RemoveRole(roleId); err != nil {
+			return append(diags, diag.Errorf("Failed to remove role %s from member %s: %s", roleId.String(), userId, err.Error())...)
+		}
+		removed[userId] = true
+	}
+
+	return diags
+}
+
+func resourceMemberRolesBulkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	serverId := getId(d.Get("server_id").(string))
+	roleId := getId(d.Get("role_id").(string))
+
+	members, err := client.Guild(serverId).GetMembers(&disgord.GetMembers{Limit: 0})
+	if err != nil {
+		return diag.Errorf("Failed to fetch members for %s: %s", serverId.String(), err.Error())
+	}
+	withRole := getMembersWithRole(members, roleId)
+
+	for _, u := range d.Get("user_ids").(*schema.Set).List() {
+		userId := u.(string)
+		if !withRole[userId] {
+			continue
+		}
+		if err := client.Guild(serverId).Member(getId(userId)).RemoveRole(roleId); err != nil {
+			return diag.Errorf("Failed to remove role %s from member %s: %s", roleId.String(), userId, err.Error())
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,83 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestMemberRolesBulkAllMembers(t *testing.T) {
+	members := `[{"user": {"id": "2"}, "roles": ["5"]}, {"user": {"id": "3"}, "roles": []}, {"user": {"id": "4"}, "roles": []}]`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/members":           {{status: http.StatusOK, body: members}},
+		"PUT /guilds/1/members/3/roles/5": {{status: http.StatusNoContent}},
+		"PUT /guilds/1/members/4/roles/5": {{status: http.StatusNoContent}},
+	})
+
+	r := resourceDiscordMemberRolesBulk()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id":   "1",
+		"role_id":     "5",
+		"all_members": true,
+	})
+
+	if diags := resourceMemberRolesBulkCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+	for _, route := range []string{"PUT /guilds/1/members/3/roles/5", "PUT /guilds/1/members/4/roles/5"} {
+		if ac := transport.count(route); ac != 1 {
+			t.Errorf("%s Error: ex: %v, ac: %v", route, 1, ac)
+		}
+	}
+	if ac := transport.count("PUT /guilds/1/members/2/roles/5"); ac != 0 {
+		t.Errorf("PUT /guilds/1/members/2/roles/5 Error: ex: %v, ac: %v", 0, ac)
+	}
+
+	// The mock still lists members 3 and 4 without the role, which has to show up as a diff.
+	if diags := resourceMemberRolesBulkRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := d.Get("all_members").(bool); ac {
+		t.Errorf("all_members Error: ex: %v, ac: %v", false, ac)
+	}
+}
+
+func TestMemberRolesBulkKeepsNonMembers(t *testing.T) {
+	for _, exclusive := range []bool{false, true} {
+		members := `[{"user": {"id": "2"}, "roles": ["5"]}, {"user": {"id": "3"}, "roles": []}]`
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/members": {{status: http.StatusOK, body: members}},
+		})
+
+		r := resourceDiscordMemberRolesBulk()
+		config := map[string]interface{}{
+			"server_id": "1",
+			"role_id":   "5",
+			"user_ids":  []interface{}{"2", "9"},
+			"exclusive": exclusive,
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+
+		diags := resourceMemberRolesBulkCreate(context.Background(), d, c)
+		if diags.HasError() || len(diags) != 1 {
+			t.Fatalf("exclusive: %v - create Error: ex: %v, ac: %v", exclusive, "a warning about user 9", diags)
+		}
+		if ac := transport.count("PUT /guilds/1/members/9/roles/5"); ac != 0 {
+			t.Errorf("exclusive: %v - PUT /guilds/1/members/9/roles/5 Error: ex: %v, ac: %v", exclusive, 0, ac)
+		}
+
+		if diags := resourceMemberRolesBulkRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("exclusive: %v - read Error: ex: %v, ac: %v", exclusive, nil, diags)
+		}
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+		if err != nil {
+			t.Fatalf("exclusive: %v - diff Error: ex: %v, ac: %v", exclusive, nil, err)
+		}
+		if diff != nil && len(diff.Attributes) > 0 {
+			t.Errorf("exclusive: %v - plan Error: ex: %v, ac: %v", exclusive, nil, diff.Attributes)
+		}
+	}
+}
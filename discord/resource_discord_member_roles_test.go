@@ -0,0 +1,102 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestGetMemberRoles(t *testing.T) {
+	member := &disgord.Member{Roles: []disgord.Snowflake{1, 2, 3}}
+	oldItems := []interface{}{
+		map[string]interface{}{"role_id": "3", "has_role": true},
+	}
+	items := []interface{}{
+		map[string]interface{}{"role_id": "2", "has_role": false},
+		map[string]interface{}{"role_id": "4", "has_role": true},
+		map[string]interface{}{"role_id": "5", "has_role": true},
+	}
+
+	params := []struct {
+		exclusive bool
+		expected  []disgord.Snowflake
+	}{
+		// Role 1 isn't managed and is kept, 2 is unset and 3 was dropped from the config.
+		{exclusive: false, expected: []disgord.Snowflake{1, 4, 5}},
+		// Only the roles with has_role are left.
+		{exclusive: true, expected: []disgord.Snowflake{4, 5}},
+	}
+
+	for _, p := range params {
+		roles := getMemberRoles(member, oldItems, items, p.exclusive)
+		if !reflect.DeepEqual(roles, p.expected) {
+			t.Errorf("exclusive: %v - roles Error: ex: %v, ac: %v", p.exclusive, p.expected, roles)
+		}
+	}
+}
+
+func TestMemberRolesBypassVerification(t *testing.T) {
+	params := []struct {
+		config   map[string]interface{}
+		flags    string
+		expected string
+	}{
+		{config: map[string]interface{}{"bypass_verification": true}, flags: "3", expected: `{"roles":["5"],"flags":7}`},
+		{config: map[string]interface{}{"bypass_verification": false}, flags: "7", expected: `{"roles":["5"],"flags":3}`},
+		// Without bypass_verification the flags are left as they are.
+		{config: map[string]interface{}{}, flags: "7", expected: `{"roles":["5"]}`},
+	}
+
+	for _, p := range params {
+		member := `{"user": {"id": "2"}, "roles": [], "flags": ` + p.flags + `}`
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/members/2":   {{status: http.StatusOK, body: member}},
+			"PATCH /guilds/1/members/2": {{status: http.StatusOK, body: member}},
+		})
+
+		config := map[string]interface{}{
+			"server_id": "1",
+			"user_id":   "2",
+			"role":      []interface{}{map[string]interface{}{"role_id": "5", "has_role": true}},
+		}
+		for k, v := range p.config {
+			config[k] = v
+		}
+		d := testResourceDataDiff(t, resourceDiscordMemberRoles(), &terraform.InstanceState{}, config, nil)
+		d.MarkNewResource()
+		d.SetId("1:2")
+		if diags := resourceMemberRolesUpdate(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("config: %v - update Error: ex: %v, ac: %v", p.config, nil, diags)
+		}
+
+		var ac string
+		for i, route := range transport.requests {
+			if route == "PATCH /guilds/1/members/2" {
+				ac = transport.bodies[i]
+			}
+		}
+		if ac != p.expected {
+			t.Errorf("config: %v - payload Error: ex: %v, ac: %v", p.config, p.expected, ac)
+		}
+	}
+
+	c, _ := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/members/2": {{status: http.StatusOK, body: `{"user": {"id": "2"}, "roles": ["5"], "flags": 6}`}},
+	})
+	d := schema.TestResourceDataRaw(t, resourceDiscordMemberRoles().Schema, map[string]interface{}{"server_id": "1", "user_id": "2"})
+	d.SetId("1:2")
+	if diags := resourceMemberRolesRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := d.Get("flags").(int); ac != 6 {
+		t.Errorf("flags Error: ex: %v, ac: %v", 6, ac)
+	}
+	if ac := d.Get("bypass_verification").(bool); !ac {
+		t.Errorf("bypass_verification Error: ex: %v, ac: %v", true, ac)
+	}
+}
@@ -1,7 +1,10 @@
 package discord
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -289,15 +292,45 @@ func resourceMessageCreate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if d.Get("pinned").(bool) {
-		err = client.Channel(channelId).Message(message.ID).Pin()
-		if err != nil {
-			diags = append(diags, diag.Errorf("Failed to pin message %s in %s: %s", message.ID.String(), channelId.String(), err.Error())...)
-		}
+		diags = append(diags, pinMessage(ctx, m, channelId, message.ID)...)
 	}
 
 	return diags
 }
 
+// maxChannelPins is the number of messages Discord lets a channel pin.
+const maxChannelPins = 50
+
+// checkChannelPinLimit fails when the channel has no pin left, which Discord only reports with a generic error.
+func checkChannelPinLimit(ctx context.Context, m interface{}, channelId disgord.Snowflake) error {
+	var pins []json.RawMessage
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/channels/%s/pins", channelId.String()), nil, &pins); err != nil {
+		return err
+	}
+	if len(pins) >= maxChannelPins {
+		return fmt.Errorf("channel %s already has %d pinned messages, which is Discord's limit. Unpin a message first", channelId.String(), len(pins))
+	}
+
+	return nil
+}
+
+func pinMessage(ctx context.Context, m interface{}, channelId disgord.Snowflake, messageId disgord.Snowflake) diag.Diagnostics {
+	client := m.(*Context).Client
+
+	if err := checkChannelPinLimit(ctx, m, channelId); err != nil {
+		return diag.Errorf("Failed to pin message %s in %s: %s", messageId.String(), channelId.String(), err.Error())
+	}
+
+	if err := client.Channel(channelId).Message(messageId).Pin(); err != nil {
+		if isDiscordError(err, discordErrorMaxPins) {
+			return diag.Errorf("Failed to pin message %s in %s: the channel reached Discord's limit of %d pinned messages", messageId.String(), channelId.String(), maxChannelPins)
+		}
+		return diag.Errorf("Failed to pin message %s in %s: %s", messageId.String(), channelId.String(), err.Error())
+	}
+
+	return nil
+}
+
 func resourceMessageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
@@ -384,6 +417,14 @@ func resourceMessageUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 	d.Set("edited_timestamp", message.EditedTimestamp.Format(time.RFC3339))
 
+	if d.HasChange("pinned") {
+		if d.Get("pinned").(bool) {
+			diags = append(diags, pinMessage(ctx, m, channelId, messageId)...)
+		} else if err := builder.Unpin(); err != nil {
+			diags = append(diags, diag.Errorf("Failed to unpin message %s in %s: %s", messageId.String(), channelId.String(), err.Error())...)
+		}
+	}
+
 	return diags
 }
 
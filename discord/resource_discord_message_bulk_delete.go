@@ -0,0 +1,212 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+// Discord only bulk-deletes messages younger than 14 days, a margin keeps messages close to it from failing the request.
+const bulkDeleteMaxAge = 14*24*time.Hour - time.Minute
+
+var messagePageSize = 100
+
+type channelMessage struct {
+	ID disgord.Snowflake `json:"id"`
+}
+
+type bulkDelete struct {
+	Messages []string `json:"messages"`
+}
+
+func resourceDiscordMessageBulkDelete() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMessageBulkDeleteCreate,
+		ReadContext:   resourceMessageBulkDeleteRead,
+		DeleteContext: resourceMessageBulkDeleteDelete,
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"message_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"message_count", "older_than"},
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					if v := val.(int); v < 1 {
+						errors = append(errors, fmt.Errorf("%s must be at least 1, got: %d", key, v))
+					}
+
+					return
+				},
+			},
+			"older_than": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"message_count", "older_than"},
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					if _, err := time.ParseDuration(val.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%s must be a duration like \"24h\": %s", key, err.Error()))
+					}
+
+					return
+				},
+			},
+			"delete_old_messages": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Delete messages older than 14 days one by one, instead of skipping them.",
+			},
+			"deleted": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"skipped": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// getMessagesToDelete pages through the messages of a channel from the newest one, either the latest count messages
+// or all messages sent before the cutoff.
+func getMessagesToDelete(ctx context.Context, m interface{}, channelId disgord.Snowflake, count int, cutoff time.Time) ([]disgord.Snowflake, error) {
+	messages := make([]disgord.Snowflake, 0)
+	var before disgord.Snowflake
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(messagePageSize))
+		if !before.IsZero() {
+			query.Set("before", before.String())
+		}
+
+		var page []channelMessage
+		path := fmt.Sprintf("/channels/%s/messages?%s", channelId.String(), query.Encode())
+		if err := discordRequest(ctx, m, http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+
+		for _, message := range page {
+			if count == 0 && !message.ID.Date().Before(cutoff) {
+				continue
+			}
+			messages = append(messages, message.ID)
+			if len(messages) == count {
+				return messages, nil
+			}
+		}
+
+		if len(page) < messagePageSize {
+			return messages, nil
+		}
+		before = page[len(page)-1].ID
+	}
+}
+
+func deleteMessage(ctx context.Context, m interface{}, channelId disgord.Snowflake, messageId disgord.Snowflake) error {
+	return discordRequest(ctx, m, http.MethodDelete, fmt.Sprintf("/channels/%s/messages/%s", channelId.String(), messageId.String()), nil, nil)
+}
+
+func resourceMessageBulkDeleteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	channelId := getId(d.Get("channel_id").(string))
+	now := time.Now()
+	cutoff := now
+	if v, ok := d.GetOk("older_than"); ok {
+		age, _ := time.ParseDuration(v.(string))
+		cutoff = now.Add(-age)
+	}
+
+	messages, err := getMessagesToDelete(ctx, m, channelId, d.Get("message_count").(int), cutoff)
+	if err != nil {
+		return diag.Errorf("Failed to fetch messages of channel %s: %s", channelId.String(), err.Error())
+	}
+
+	recent := make([]string, 0, len(messages))
+	old := make([]disgord.Snowflake, 0)
+	for _, messageId := range messages {
+		if now.Sub(messageId.Date()) < bulkDeleteMaxAge {
+			recent = append(recent, messageId.String())
+		} else {
+			old = append(old, messageId)
+		}
+	}
+
+	deleted := 0
+	d.SetId(channelId.String())
+	for start := 0; start < len(recent); start += messagePageSize {
+		end := start + messagePageSize
+		if end > len(recent) {
+			end = len(recent)
+		}
+
+		// The bulk-delete endpoint needs at least 2 messages.
+		if end-start == 1 {
+			err = deleteMessage(ctx, m, channelId, getId(recent[start]))
+		} else {
+			err = discordRequest(ctx, m, http.MethodPost, fmt.Sprintf("/channels/%s/messages/bulk-delete", channelId.String()), &bulkDelete{
+				Messages: recent[start:end],
+			}, nil)
+		}
+		if err != nil {
+			d.Set("deleted", deleted)
+			return append(diags, diag.Errorf("Failed to delete messages of channel %s: %s", channelId.String(), err.Error())...)
+		}
+		deleted += end - start
+	}
+
+	skipped := 0
+	if d.Get("delete_old_messages").(bool) {
+		for _, messageId := range old {
+			if err := deleteMessage(ctx, m, channelId, messageId); err != nil {
+				d.Set("deleted", deleted)
+				return append(diags, diag.Errorf("Failed to delete message %s: %s", messageId.String(), err.Error())...)
+			}
+			deleted++
+		}
+	} else if len(old) > 0 {
+		skipped = len(old)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Skipped %d messages older than 14 days in channel %s", skipped, channelId.String()),
+			Detail:   "Discord can't bulk-delete them. Set delete_old_messages to delete them one by one.",
+		})
+	}
+
+	d.Set("deleted", deleted)
+	d.Set("skipped", skipped)
+
+	return diags
+}
+
+func resourceMessageBulkDeleteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// A bulk delete is a one-shot action, there is nothing to read back from Discord.
+
+	return diags
+}
+
+func resourceMessageBulkDeleteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// noop
+
+	return diags
+}
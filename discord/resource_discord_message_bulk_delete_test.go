@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testSnowflake(at time.Time) disgord.Snowflake {
+	return disgord.Snowflake(uint64(at.UnixMilli()-1420070400000) << 22)
+}
+
+func TestMessageBulkDelete(t *testing.T) {
+	now := time.Now()
+	newest, recent, old := testSnowflake(now.Add(-time.Minute)), testSnowflake(now.Add(-time.Hour)), testSnowflake(now.Add(-20*24*time.Hour))
+	messages := fmt.Sprintf(`[{"id": "%s"}, {"id": "%s"}, {"id": "%s"}]`, newest, recent, old)
+
+	params := []struct {
+		deleteOld bool
+		deleted   int
+		skipped   int
+	}{
+		{deleteOld: false, deleted: 1, skipped: 1},
+		{deleteOld: true, deleted: 2, skipped: 0},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /channels/1/messages":                            {{status: http.StatusOK, body: messages}},
+			fmt.Sprintf("DELETE /channels/1/messages/%s", recent): {{status: http.StatusNoContent}},
+			fmt.Sprintf("DELETE /channels/1/messages/%s", old):    {{status: http.StatusNoContent}},
+		})
+
+		r := resourceDiscordMessageBulkDelete()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"channel_id":          "1",
+			"older_than":          "30m",
+			"delete_old_messages": p.deleteOld,
+		})
+
+		diags := resourceMessageBulkDeleteCreate(context.Background(), d, c)
+		if diags.HasError() {
+			t.Fatalf("delete_old_messages: %v - create Error: ex: %v, ac: %v", p.deleteOld, nil, diags)
+		}
+		if ac := d.Get("deleted").(int); ac != p.deleted {
+			t.Errorf("delete_old_messages: %v - deleted Error: ex: %v, ac: %v", p.deleteOld, p.deleted, ac)
+		}
+		if ac := d.Get("skipped").(int); ac != p.skipped {
+			t.Errorf("delete_old_messages: %v - skipped Error: ex: %v, ac: %v", p.deleteOld, p.skipped, ac)
+		}
+		if ac := transport.count(fmt.Sprintf("DELETE /channels/1/messages/%s", newest)); ac != 0 {
+			t.Errorf("delete_old_messages: %v - newest message Error: ex: %v, ac: %v", p.deleteOld, 0, ac)
+		}
+		if ac := len(diags); ac != p.skipped {
+			t.Errorf("delete_old_messages: %v - warnings Error: ex: %v, ac: %v", p.deleteOld, p.skipped, diags)
+		}
+	}
+
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /channels/1/messages":              {{status: http.StatusOK, body: messages}},
+		"POST /channels/1/messages/bulk-delete": {{status: http.StatusNoContent}},
+	})
+	d := schema.TestResourceDataRaw(t, resourceDiscordMessageBulkDelete().Schema, map[string]interface{}{
+		"channel_id":    "1",
+		"message_count": 2,
+	})
+	if diags := resourceMessageBulkDeleteCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("message_count - create Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	expected := fmt.Sprintf(`{"messages":["%s","%s"]}`, newest, recent)
+	var ac string
+	for i, route := range transport.requests {
+		if route == "POST /channels/1/messages/bulk-delete" {
+			ac = transport.bodies[i]
+		}
+	}
+	if ac != expected {
+		t.Errorf("message_count - payload Error: ex: %v, ac: %v", expected, ac)
+	}
+}
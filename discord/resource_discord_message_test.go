@@ -0,0 +1,41 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPinMessageLimit(t *testing.T) {
+	params := []struct {
+		pins int
+		err  bool
+	}{
+		{pins: 49, err: false},
+		{pins: 50, err: true},
+	}
+
+	for _, p := range params {
+		pins := "[" + strings.TrimSuffix(strings.Repeat(`{"id": "1"},`, p.pins), ",") + "]"
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /channels/1/pins":   {{status: http.StatusOK, body: pins}},
+			"PUT /channels/1/pins/2": {{status: http.StatusNoContent}},
+		})
+
+		diags := pinMessage(context.Background(), c, 1, 2)
+		if diags.HasError() != p.err {
+			t.Errorf("pins: %v - error Error: ex: %v, ac: %v", p.pins, p.err, diags)
+		}
+		if p.err && !strings.Contains(diags[0].Summary, "Discord's limit") {
+			t.Errorf("pins: %v - message Error: ex: %v, ac: %v", p.pins, "Discord's limit", diags[0].Summary)
+		}
+		expected := 1
+		if p.err {
+			expected = 0
+		}
+		if ac := transport.count("PUT /channels/1/pins/2"); ac != expected {
+			t.Errorf("pins: %v - requests Error: ex: %v, ac: %v", p.pins, expected, ac)
+		}
+	}
+}
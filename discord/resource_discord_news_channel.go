@@ -10,13 +10,20 @@ func resourceDiscordNewsChannel() *schema.Resource {
 		ReadContext:   resourceChannelRead,
 		UpdateContext: resourceChannelUpdate,
 		DeleteContext: resourceChannelDelete,
+		CustomizeDiff: customizeChannelDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceChannelImport("news"),
 		},
 		Schema: getChannelSchema("news", map[string]*schema.Schema{
 			"topic": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTopicLength(1024),
+			},
+			"default_thread_rate_limit_per_user": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateRateLimitPerUser,
 			},
 		}),
 	}
@@ -2,6 +2,7 @@ package discord
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/andersfylling/disgord"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -35,6 +36,31 @@ func resourceDiscordRole() *schema.Resource {
 				Optional: true,
 				Default:  0,
 				ForceNew: false,
+				// With add_permissions or remove_permissions the bits are only managed partly, the others are kept as they are.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					if !hasIncrementalPermissions(d) {
+						return false
+					}
+					current, err := strconv.ParseUint(old, 10, 64)
+
+					return err == nil && mergeRolePermissions(current, d) == current
+				},
+			},
+			"add_permissions": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validatePermissionName},
+				Set:           schema.HashString,
+				ConflictsWith: []string{"permissions"},
+				Description:   "Names of permissions the role must have, the other permissions of the role are kept.",
+			},
+			"remove_permissions": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validatePermissionName},
+				Set:           schema.HashString,
+				ConflictsWith: []string{"permissions"},
+				Description:   "Names of permissions the role must not have, the other permissions of the role are kept.",
 			},
 			"color": {
 				Type:     schema.TypeInt,
@@ -53,10 +79,11 @@ func resourceDiscordRole() *schema.Resource {
 				Default:  false,
 				ForceNew: false,
 			},
+			// Computed so that roles ordered by discord_role_order can leave it out.
 			"position": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				Default:  1,
+				Computed: true,
 				ForceNew: false,
 
 				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
@@ -68,18 +95,66 @@ func resourceDiscordRole() *schema.Resource {
 
 					return
 				},
+				// The position is resolved from above_role_id or below_role_id when either is set.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get("above_role_id").(string) != "" || d.Get("below_role_id").(string) != ""
+				},
+			},
+			"above_role_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"below_role_id"},
+				Description:   "ID of the role to place this role directly above, instead of setting position.",
+			},
+			"below_role_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"above_role_id"},
+				Description:   "ID of the role to place this role directly below, instead of setting position.",
 			},
 			"managed": {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"compute_member_count": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Count the members with the role into member_count, which pages through every member of the server.",
+			},
+			"member_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"tags": roleTagsSchema(),
 		},
 	}
 }
 
 func resourceRoleImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	if serverId, name, ok := parseNameImportId(data.Id()); ok {
+		roles, err := i.(*Context).Client.Guild(getId(serverId)).GetRoles()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch roles for %s: %s", serverId, err.Error())
+		}
+
+		names := make(map[disgord.Snowflake]string, len(roles))
+		for _, role := range roles {
+			names[role.ID] = role.Name
+		}
+		roleId, err := findIdByName("role", names, name)
+		if err != nil {
+			return nil, err
+		}
+
+		data.SetId(roleId.String())
+		data.Set("server_id", serverId)
+
+		return schema.ImportStatePassthroughContext(ctx, data, i)
+	}
+
 	if serverId, roleId, err := getBothIds(data.Id()); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected server_id:role_id or server_id/name", data.Id())
 	} else {
 		data.SetId(roleId.String())
 		data.Set("server_id", serverId.String())
@@ -98,9 +173,14 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("Server does not exist with that ID: %s", serverId)
 	}
 
+	rolePermissions := uint64(d.Get("permissions").(int))
+	if hasIncrementalPermissions(d) {
+		rolePermissions = mergeRolePermissions(0, d)
+	}
+
 	role, err := client.Guild(serverId).CreateRole(&disgord.CreateGuildRole{
 		Name:        d.Get("name").(string),
-		Permissions: uint64(d.Get("permissions").(int)),
+		Permissions: rolePermissions,
 		Color:       uint(d.Get("color").(int)),
 		Hoist:       d.Get("hoist").(bool),
 		Mentionable: d.Get("mentionable").(bool),
@@ -109,7 +189,13 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("Failed to create role for %s: %s", serverId.String(), err.Error())
 	}
 
-	if newPosition, ok := d.GetOk("position"); ok {
+	if targetId, above, ok := getRelativeRoleTarget(d); ok {
+		if position, moveDiags := updateRelativeRolePosition(client, serverId, role.ID, targetId, above); moveDiags.HasError() {
+			diags = append(diags, moveDiags...)
+		} else {
+			d.Set("position", position)
+		}
+	} else if newPosition, ok := d.GetOk("position"); ok {
 		var oldRole *disgord.Role
 		for _, r := range server.Roles {
 			if r.Position == newPosition.(int) {
@@ -137,29 +223,69 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 	return diags
 }
 
+// getRelativeRoleTarget returns the role configured in above_role_id or below_role_id and whether it's above_role_id.
+func getRelativeRoleTarget(d *schema.ResourceData) (disgord.Snowflake, bool, bool) {
+	if v, ok := d.GetOk("above_role_id"); ok {
+		return getId(v.(string)), true, true
+	}
+	if v, ok := d.GetOk("below_role_id"); ok {
+		return getId(v.(string)), false, true
+	}
+
+	return 0, false, false
+}
+
 func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
 
 	serverId := getId(d.Get("server_id").(string))
-	server, err := client.Guild(serverId).Get()
+	roles, err := getServerRoles(ctx, m, serverId)
 	if err != nil {
-		return diag.Errorf("Failed to fetch server %s: %s", serverId.String(), err.Error())
+		return diag.Errorf("Failed to fetch roles of server %s: %s", serverId.String(), err.Error())
 	}
 
-	if role, err := server.Role(getId(d.Id())); err != nil {
-		return diag.Errorf("Failed to fetch role %s: %s", d.Id(), err.Error())
-	} else {
-		d.Set("name", role.Name)
-		d.Set("position", role.Position)
-		d.Set("color", role.Color)
-		d.Set("hoist", role.Hoist)
-		d.Set("mentionable", role.Mentionable)
-		d.Set("permissions", role.Permissions)
-		d.Set("managed", role.Managed)
-
+	// The role was deleted outside of Terraform, the next plan creates it again.
+	role := findServerRole(roles, getId(d.Id()))
+	if role == nil {
+		d.SetId("")
 		return diags
 	}
+	setRoleData(d, &role.Role)
+	d.Set("tags", flattenRoleTags(role.Tags))
+
+	// A role moved away from its target role drops the target from the state, so that the next plan moves it back.
+	if targetId, above, ok := getRelativeRoleTarget(d); ok {
+		serverRoles := make([]*disgord.Role, 0, len(roles))
+		for _, r := range roles {
+			serverRoles = append(serverRoles, &r.Role)
+		}
+		if !isRolePlacedRelative(serverRoles, role.ID, targetId, above) {
+			d.Set(map[bool]string{true: "above_role_id", false: "below_role_id"}[above], "")
+		}
+	}
+
+	if d.Get("compute_member_count").(bool) {
+		count, err := countRoleMembers(client, serverId, getId(d.Id()))
+		if err != nil {
+			return diag.Errorf("Failed to fetch members for %s: %s", serverId.String(), err.Error())
+		}
+		d.Set("member_count", count)
+	}
+
+	return diags
+}
+
+// setRoleData sets every attribute Discord reports for the role, hoist and mentionable included, so that toggling
+// them in the Discord client shows up as drift.
+func setRoleData(d *schema.ResourceData, role *disgord.Role) {
+	d.Set("name", role.Name)
+	d.Set("position", role.Position)
+	d.Set("color", role.Color)
+	d.Set("hoist", role.Hoist)
+	d.Set("mentionable", role.Mentionable)
+	d.Set("permissions", role.Permissions)
+	d.Set("managed", role.Managed)
 }
 
 func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -178,7 +304,15 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("Failed to fetch role %s: %s", d.Id(), err.Error())
 	}
 
-	if d.HasChange("position") {
+	if targetId, above, ok := getRelativeRoleTarget(d); ok {
+		if d.HasChanges("above_role_id", "below_role_id") {
+			if position, moveDiags := updateRelativeRolePosition(client, serverId, roleId, targetId, above); moveDiags.HasError() {
+				diags = append(diags, moveDiags...)
+			} else {
+				d.Set("position", position)
+			}
+		}
+	} else if d.HasChange("position") {
 		_, newPosition := d.GetChange("position")
 		var oldRole *disgord.Role
 		for _, r := range server.Roles {
@@ -206,6 +340,9 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		newMentionable = d.Get("mentionable").(bool)
 		newPermissions = disgord.PermissionBit(d.Get("permissions").(int))
 	)
+	if hasIncrementalPermissions(d) {
+		newPermissions = disgord.PermissionBit(mergeRolePermissions(uint64(role.Permissions), d))
+	}
 	if _, v := d.GetChange("color"); v.(int) > 0 {
 		newColor = v.(int)
 	} else {
@@ -221,13 +358,7 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	}); err != nil {
 		return diag.Errorf("Failed to update role %s: %s", d.Id(), err.Error())
 	} else {
-		d.Set("name", role.Name)
-		d.Set("position", role.Position)
-		d.Set("color", role.Color)
-		d.Set("hoist", role.Hoist)
-		d.Set("mentionable", role.Mentionable)
-		d.Set("permissions", role.Permissions)
-		d.Set("managed", role.Managed)
+		setRoleData(d, role)
 
 		return diags
 	}
@@ -240,7 +371,7 @@ func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface
 	serverId := getId(d.Get("server_id").(string))
 	roleId := getId(d.Id())
 
-	if err := client.Guild(serverId).Role(roleId).Delete(); err != nil {
+	if err := client.Guild(serverId).Role(roleId).Delete(); err != nil && !isDiscordError(err, discordErrorUnknownRole) {
 		return diag.Errorf("Failed to delete role: %s", err.Error())
 	}
 
@@ -9,7 +9,7 @@ import (
 
 func resourceDiscordRoleEveryone() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceRoleEveryoneRead,
+		CreateContext: resourceRoleEveryoneCreate,
 		ReadContext:   resourceRoleEveryoneRead,
 		UpdateContext: resourceRoleEveryoneUpdate,
 		DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
@@ -29,10 +29,11 @@ func resourceDiscordRoleEveryone() *schema.Resource {
 				ForceNew: true,
 			},
 			"permissions": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  0,
-				ForceNew: false,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ForceNew:     false,
+				ValidateFunc: validateRolePermissions,
 			},
 		},
 	}
@@ -45,6 +46,15 @@ func resourceRoleEveryoneImport(ctx context.Context, data *schema.ResourceData,
 	return schema.ImportStatePassthroughContext(ctx, data, i)
 }
 
+func resourceRoleEveryoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The role already exists, creating the resource applies the configured permissions to it.
+	diags = append(diags, resourceRoleEveryoneUpdate(ctx, d, m)...)
+
+	return diags
+}
+
 func resourceRoleEveryoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
@@ -60,7 +70,7 @@ func resourceRoleEveryoneRead(ctx context.Context, d *schema.ResourceData, m int
 	if role, err := server.Role(serverId); err != nil {
 		return diag.Errorf("Failed to fetch role %s: %s", d.Id(), err.Error())
 	} else {
-		d.Set("permissions", role.Permissions)
+		d.Set("permissions", int(role.Permissions))
 
 		return diags
 	}
@@ -78,7 +88,7 @@ func resourceRoleEveryoneUpdate(ctx context.Context, d *schema.ResourceData, m i
 	}); err != nil {
 		return diag.Errorf("Failed to update role %s: %s", d.Id(), err.Error())
 	} else {
-		d.Set("permissions", role.Permissions)
+		d.Set("permissions", int(role.Permissions))
 
 		return diags
 	}
@@ -0,0 +1,111 @@
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+func resourceDiscordRoleOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRoleOrderCreate,
+		ReadContext:   resourceRoleOrderRead,
+		UpdateContext: resourceRoleOrderUpdate,
+		DeleteContext: resourceRoleOrderDelete,
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the roles from the highest to the lowest.",
+			},
+		},
+	}
+}
+
+func getRoleOrderIds(d *schema.ResourceData) []disgord.Snowflake {
+	roleIds := make([]disgord.Snowflake, 0)
+	for _, id := range d.Get("role_ids").([]interface{}) {
+		roleIds = append(roleIds, getId(id.(string)))
+	}
+
+	return roleIds
+}
+
+func resourceRoleOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(d.Get("server_id").(string))
+
+	diags = append(diags, resourceRoleOrderUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceRoleOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	serverId := getId(d.Get("server_id").(string))
+	roles, err := client.Guild(serverId).GetRoles()
+	if err != nil {
+		return diag.Errorf("Failed to fetch roles of server %s: %s", serverId.String(), err.Error())
+	}
+
+	// The listed roles are read back in their current order, deleted roles are dropped.
+	roleIds := getRoleOrderIds(d)
+	ordered := sortRolesByPosition(roles)
+	current := make([]string, 0, len(roleIds))
+	for i := len(ordered) - 1; i >= 0; i-- {
+		for _, id := range roleIds {
+			if ordered[i].ID == id {
+				current = append(current, id.String())
+				break
+			}
+		}
+	}
+
+	d.Set("role_ids", current)
+
+	return diags
+}
+
+func resourceRoleOrderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	serverId := getId(d.Get("server_id").(string))
+	roles, err := client.Guild(serverId).GetRoles()
+	if err != nil {
+		return diag.Errorf("Failed to fetch roles of server %s: %s", serverId.String(), err.Error())
+	}
+
+	params, err := getRoleOrderPositions(roles, serverId, getRoleOrderIds(d))
+	if err != nil {
+		return diag.Errorf("Failed to order roles of server %s: %s", serverId.String(), err.Error())
+	}
+
+	if _, err := client.Guild(serverId).UpdateRolePositions(params); err != nil {
+		return diag.Errorf("Failed to order roles of server %s: %s", serverId.String(), err.Error())
+	}
+
+	diags = append(diags, resourceRoleOrderRead(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceRoleOrderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The roles stay where they are, there is no order to go back to.
+
+	return diags
+}
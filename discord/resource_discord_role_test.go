@@ -0,0 +1,122 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceRoleHoistMentionableDrift(t *testing.T) {
+	params := []struct {
+		hoist       bool
+		mentionable bool
+		drift       []string
+	}{
+		{hoist: false, mentionable: false, drift: []string{}},
+		{hoist: true, mentionable: false, drift: []string{"hoist"}},
+		{hoist: false, mentionable: true, drift: []string{"mentionable"}},
+		{hoist: true, mentionable: true, drift: []string{"hoist", "mentionable"}},
+	}
+
+	for _, p := range params {
+		role := fmt.Sprintf(`{"id": "5", "name": "role", "position": 1, "hoist": %t, "mentionable": %t}`, p.hoist, p.mentionable)
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1":       {{status: http.StatusOK, body: fmt.Sprintf(`{"id": "1", "name": "server", "roles": [%s]}`, role)}},
+			"GET /guilds/1/roles": {{status: http.StatusOK, body: fmt.Sprintf(`[%s]`, role)}},
+		})
+
+		r := resourceDiscordRole()
+		config := map[string]interface{}{"server_id": "1", "name": "role", "position": 1, "hoist": false, "mentionable": false}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+		d.SetId("5")
+		if diags := resourceRoleRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("hoist: %v, mentionable: %v - read Error: ex: %v, ac: %v", p.hoist, p.mentionable, nil, diags)
+		}
+		if d.Get("hoist").(bool) != p.hoist || d.Get("mentionable").(bool) != p.mentionable {
+			t.Errorf("hoist: %v, mentionable: %v - state Error: ex: %v %v, ac: %v %v",
+				p.hoist, p.mentionable, p.hoist, p.mentionable, d.Get("hoist"), d.Get("mentionable"))
+		}
+
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+		if err != nil {
+			t.Fatalf("hoist: %v, mentionable: %v - diff Error: ex: %v, ac: %v", p.hoist, p.mentionable, nil, err)
+		}
+		attributes := map[string]*terraform.ResourceAttrDiff{}
+		if diff != nil {
+			attributes = diff.Attributes
+		}
+		if len(attributes) != len(p.drift) {
+			t.Errorf("hoist: %v, mentionable: %v - plan Error: ex: %v, ac: %v", p.hoist, p.mentionable, p.drift, attributes)
+		}
+		for _, k := range p.drift {
+			if a, ok := attributes[k]; !ok || a.New != "false" {
+				t.Errorf("hoist: %v, mentionable: %v - %s Error: ex: %v, ac: %v", p.hoist, p.mentionable, k, "false", a)
+			}
+		}
+	}
+}
+
+func TestResourceRoleReadSingleRequest(t *testing.T) {
+	params := []struct {
+		roles string
+		id    string
+	}{
+		{roles: `[{"id": "5", "name": "role", "position": 1, "tags": {"bot_id": "7"}}]`, id: "5"},
+		{roles: `[{"id": "6", "name": "other", "position": 1}]`, id: ""},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/roles": {{status: http.StatusOK, body: p.roles}},
+		})
+
+		r := resourceDiscordRole()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"server_id": "1", "name": "role"})
+		d.SetId("5")
+		if diags := resourceRoleRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("roles: %v - read Error: ex: %v, ac: %v", p.roles, nil, diags)
+		}
+		if ac := d.Id(); ac != p.id {
+			t.Errorf("roles: %v - id Error: ex: %v, ac: %v", p.roles, p.id, ac)
+		}
+		if ac := len(transport.requests); ac != 1 {
+			t.Errorf("roles: %v - requests Error: ex: %v, ac: %v", p.roles, 1, transport.requests)
+		}
+		if p.id != "" && d.Get("tags.0.bot_id").(string) != "7" {
+			t.Errorf("roles: %v - tags Error: ex: %v, ac: %v", p.roles, "7", d.Get("tags"))
+		}
+	}
+}
+
+func TestResourceRoleReadRelativePosition(t *testing.T) {
+	params := []struct {
+		key      string
+		roles    string
+		expected string
+	}{
+		{key: "above_role_id", roles: `[{"id": "1", "position": 0}, {"id": "3", "position": 1}, {"id": "5", "position": 2}]`, expected: "3"},
+		{key: "above_role_id", roles: `[{"id": "1", "position": 0}, {"id": "5", "position": 1}, {"id": "3", "position": 2}]`, expected: ""},
+		{key: "below_role_id", roles: `[{"id": "1", "position": 0}, {"id": "5", "position": 1}, {"id": "3", "position": 2}]`, expected: "3"},
+		{key: "below_role_id", roles: `[{"id": "1", "position": 0}, {"id": "5", "position": 1}, {"id": "4", "position": 2}, {"id": "3", "position": 3}]`, expected: ""},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1/roles": {{status: http.StatusOK, body: p.roles}},
+		})
+
+		r := resourceDiscordRole()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"server_id": "1", "name": "role", p.key: "3"})
+		d.SetId("5")
+		if diags := resourceRoleRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("roles: %v - read Error: ex: %v, ac: %v", p.roles, nil, diags)
+		}
+		if ac := d.Get(p.key).(string); ac != p.expected {
+			t.Errorf("roles: %v - %s Error: ex: %v, ac: %v", p.roles, p.key, p.expected, ac)
+		}
+	}
+}
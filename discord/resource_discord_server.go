@@ -2,19 +2,27 @@ package discord
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/andersfylling/disgord"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/polds/imgbase64"
 	"golang.org/x/net/context"
 )
 
+// The strictest verification levels only apply to members meeting their requirements, which is easy to miss.
+var verificationLevelWarnings = map[int]string{
+	3: "verification_level 3 (high) only lets members talk after being on the server for 10 minutes",
+	4: "verification_level 4 (very high) only lets members with a verified phone number talk on the server",
+}
+
 func baseServerSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"region": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressServerRegionDiff,
 		},
 		"verification_level": {
 			Type:     schema.TypeInt,
@@ -25,6 +33,9 @@ func baseServerSchema() map[string]*schema.Schema {
 				if v > 4 || v < 0 {
 					errors = append(errors, fmt.Errorf("verification_level must be between 0 and 4 inclusive, got: %d", v))
 				}
+				if warning, ok := verificationLevelWarnings[v]; ok {
+					warns = append(warns, warning)
+				}
 
 				return
 			},
@@ -43,13 +54,14 @@ func baseServerSchema() map[string]*schema.Schema {
 			},
 		},
 		"default_message_notifications": {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  0,
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     0,
+			Description: "Server-wide default notification setting for members: 0 notifies on all messages, 1 notifies only on mentions.",
 			ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
 				v := val.(int)
 				if v != 0 && v != 1 {
-					errors = append(errors, fmt.Errorf("default_message_notifications must be 0 or 1, got: %d", v))
+					errors = append(errors, fmt.Errorf("default_message_notifications must be 0 (all messages) or 1 (only mentions), got: %d", v))
 				}
 
 				return
@@ -60,47 +72,135 @@ func baseServerSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"afk_timeout": {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  300,
-			ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
-				v := val.(int)
-				// See: https://discord.com/developers/docs/resources/guild#guild-object-guild-structure
-				expected := []int{60, 300, 900, 1800, 3600}
-				if !contains(expected, v) {
-					errors = append(errors, fmt.Errorf("afk_timeout must be set to one of the following values: %d, but got: %d", expected, v))
-				}
-
-				return
-			},
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      300,
+			ValidateFunc: validateAFKTimeout,
 		},
 		"icon_url": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"icon_data_uri", "icon_file", "icon_from_server_id"},
 		},
 		"icon_data_uri": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"icon_url", "icon_file", "icon_from_server_id"},
+			ValidateFunc:  validateImageDataURI,
+		},
+		"icon_file": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"icon_url", "icon_data_uri", "icon_from_server_id"},
+			ValidateFunc:  validateImageFile,
+		},
+		"icon_from_server_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"icon_url", "icon_data_uri", "icon_file"},
+			Description:   "ID of another server whose current icon is copied.",
 		},
 		"icon_hash": {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
 		"splash_url": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"splash_data_uri", "splash_file", "splash_from_server_id"},
 		},
 		"splash_data_uri": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"splash_url", "splash_file", "splash_from_server_id"},
+			ValidateFunc:  validateImageDataURI,
+		},
+		"splash_file": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"splash_url", "splash_data_uri", "splash_from_server_id"},
+			ValidateFunc:  validateImageFile,
+		},
+		"splash_from_server_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"splash_url", "splash_data_uri", "splash_file"},
+			Description:   "ID of another server whose current splash is copied.",
 		},
 		"splash_hash": {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		// Computed so that an imported server, or one which leaves it out, reads the current owner without a diff.
 		"owner_id": {
 			Type:     schema.TypeString,
 			Optional: true,
+			Computed: true,
+		},
+		// Only accounts with two-factor authentication need it, which bots can't enable.
+		"mfa_code": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Current two-factor authentication code of the owner, sent along when transferring the ownership.",
+		},
+		"safety_alerts_channel_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"invites_disabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether new invites to the server are paused, e.g. during a raid.",
+		},
+		// Computed, so that servers leaving out features keep them. An explicit [] is planned by customizeServerDiff.
+		"features": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					v := val.(string)
+					if v == serverFeatureInvitesDisabled {
+						errors = append(errors, fmt.Errorf("%s is toggled with invites_disabled instead of %s", v, key))
+					} else if !contains(configurableServerFeatures, v) {
+						errors = append(errors, fmt.Errorf("%s can't be toggled, %s must be one of %v", v, key, configurableServerFeatures))
+					}
+
+					return
+				},
+			},
+			Set:         schema.HashString,
+			Description: "Features admins may toggle themselves which the server has. Features Discord grants are kept.",
+		},
+		"all_features": {
+			Type:     schema.TypeSet,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"bot_is_owner": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"max_members": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"max_presences": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		// Discord doesn't accept nsfw_level in the modify guild payload; the level is assigned by Discord itself.
+		"nsfw_level": {
+			Type:     schema.TypeInt,
+			Computed: true,
 		},
 	}
 }
@@ -112,9 +212,50 @@ func managedServerSchema() map[string]*schema.Schema {
 		Type:     schema.TypeString,
 		Required: true,
 	}
+	res["verify_exists"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Whether creating the resource fails when the bot can't access the server.",
+	}
+	// Without a configured name the current one is kept, so adopting a server doesn't rename it.
 	res["name"] = &schema.Schema{
 		Type:     schema.TypeString,
 		Optional: true,
+		Computed: true,
+	}
+	res["channel"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "text",
+					ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+						v := val.(string)
+						if _, ok := getDiscordChannelType(v); !ok {
+							errors = append(errors, fmt.Errorf("%s is not a valid channel type", v))
+						}
+
+						return
+					},
+				},
+				"category": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
 	}
 
 	return res
@@ -131,6 +272,18 @@ func serverSchema() map[string]*schema.Schema {
 		Type:     schema.TypeString,
 		Required: true,
 	}
+	res["from_template_code"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Code of a server template to create the server from, keeping the template's channels and roles.",
+	}
+	res["deletion_protection"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether destroying the server is refused. Deleting a server can't be undone.",
+	}
 
 	return res
 }
@@ -141,20 +294,46 @@ func resourceDiscordServer() *schema.Resource {
 		ReadContext:   resourceServerRead,
 		UpdateContext: resourceServerUpdate,
 		DeleteContext: resourceServerDelete,
+		CustomizeDiff: customizeServerDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceServerImport,
 		},
 
 		Schema: serverSchema(),
 	}
 }
 
+// customizeServerDiff plans turning the toggleable features off when features is set to [], which Terraform
+// otherwise takes for leaving out the computed attribute.
+func customizeServerDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+
+	features := raw.GetAttr("features")
+	if features.IsKnown() && !features.IsNull() && features.LengthInt() == 0 && d.Get("features").(*schema.Set).Len() > 0 {
+		return d.SetNew("features", []interface{}{})
+	}
+
+	return nil
+}
+
+// resourceServerImport sets the arguments Discord doesn't know about to their defaults, the read fills in the rest,
+// so that the first plan after an import is clean.
+func resourceServerImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	data.Set("deletion_protection", false)
+
+	return schema.ImportStatePassthroughContext(ctx, data, i)
+}
+
 func resourceDiscordManagedServer() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceServerManagedCreate,
-		ReadContext:   resourceServerRead,
-		UpdateContext: resourceServerUpdate,
+		ReadContext:   resourceServerManagedRead,
+		UpdateContext: resourceServerManagedUpdate,
 		DeleteContext: resourceServerManagedDelete,
+		CustomizeDiff: customizeServerDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -167,97 +346,239 @@ func resourceServerCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
 
-	icon := ""
-	if v, ok := d.GetOk("icon_url"); ok {
-		icon = imgbase64.FromRemote(v.(string))
+	icon, err := getConfiguredImage(client, d, "icon")
+	if err != nil {
+		return diag.Errorf("Failed to read icon: %s", err.Error())
 	}
-	if v, ok := d.GetOk("icon_data_uri"); ok {
-		icon = v.(string)
+	// New servers never have the feature for animated icons.
+	if err := checkAnimatedIcon(icon, nil); err != nil {
+		return diag.FromErr(err)
 	}
 
 	name := d.Get("name").(string)
-	server, err := client.CreateGuild(name, &disgord.CreateGuild{
-		Region:                  d.Get("region").(string),
-		Icon:                    icon,
-		VerificationLvl:         d.Get("verification_level").(int),
-		DefaultMsgNotifications: disgord.DefaultMessageNotificationLvl(d.Get("default_message_notifications").(int)),
-		ExplicitContentFilter:   disgord.ExplicitContentFilterLvl(d.Get("explicit_content_filter").(int)),
-		Channels:                nil,
-	})
-	if err != nil {
-		return diag.Errorf("Failed to create server: %s", err.Error())
+	templateCode := d.Get("from_template_code").(string)
+	var server *disgord.Guild
+	if templateCode != "" {
+		if err := checkServerTemplate(ctx, m, templateCode); err != nil {
+			return diag.FromErr(err)
+		}
+		server, diags = createServer(ctx, m, fmt.Sprintf("/guilds/templates/%s", templateCode), &createServerFromTemplateParams{
+			Name: name,
+			Icon: icon,
+		})
+	} else {
+		server, diags = createServer(ctx, m, "/guilds", &createServerParams{
+			Name:                        name,
+			Region:                      d.Get("region").(string),
+			Icon:                        icon,
+			VerificationLevel:           d.Get("verification_level").(int),
+			DefaultMessageNotifications: d.Get("default_message_notifications").(int),
+			ExplicitContentFilter:       d.Get("explicit_content_filter").(int),
+		})
+	}
+	if diags.HasError() {
+		return diags
 	}
 
-	channels, err := client.Guild(server.ID).GetChannels()
-	if err != nil {
-		return diag.Errorf("Failed to fetch channels for new server: %s", err.Error())
+	// Track the server right away, so that a failure in any of the edits below
+	// doesn't orphan the newly created server.
+	d.SetId(server.ID.String())
+
+	// Servers created from a template keep the template's channels.
+	if templateCode == "" {
+		channels, err := client.Guild(server.ID).GetChannels()
+		if err != nil {
+			return diag.Errorf("Failed to fetch channels for new server: %s", err.Error())
+		}
+
+		for _, channel := range channels {
+			if _, err := client.Channel(channel.ID).Delete(); err != nil {
+				return diag.Errorf("Failed to delete channel for new server: %s", err.Error())
+			}
+		}
 	}
 
-	for _, channel := range channels {
-		if _, err := client.Channel(channel.ID).Delete(); err != nil {
-			return diag.Errorf("Failed to delete channel for new server: %s", err.Error())
+	// The settings which can't be passed when creating the server are sent in a single edit.
+	edit, diags := getServerCreateEdit(client, server, d)
+	if diags.HasError() {
+		return diags
+	}
+	if edit != nil {
+		if err := updateGuildExtras(ctx, m, server.ID, edit); err != nil {
+			return getServerCreateEditError(server.ID, edit, err)
+		}
+		if edit.Features != nil {
+			server.Features = *edit.Features
 		}
 	}
 
-	splash := ""
-	edit := false
-	if v, ok := d.GetOk("splash_url"); ok {
-		splash = imgbase64.FromRemote(v.(string))
+	if _, ok := d.GetOk("owner_id"); !ok {
+		d.Set("owner_id", server.OwnerID.String())
 	}
-	if v, ok := d.GetOk("splash_data_uri"); ok {
-		splash = v.(string)
+	d.Set("icon_hash", server.Icon)
+	d.Set("splash_hash", server.Splash)
+
+	return diags
+}
+
+// createServerRetries is how often creating a server is retried after a rate limit or a Discord outage,
+// createServerRetryDelay is the delay before the first retry and doubles with every further one.
+var (
+	createServerRetries    = 3
+	createServerRetryDelay = 2 * time.Second
+)
+
+// createServerParams is sent instead of disgord.CreateGuild, which always sends the region.
+// Discord picks the region automatically when it's left out.
+type createServerParams struct {
+	Name                        string `json:"name"`
+	Region                      string `json:"region,omitempty"`
+	Icon                        string `json:"icon,omitempty"`
+	VerificationLevel           int    `json:"verification_level"`
+	DefaultMessageNotifications int    `json:"default_message_notifications"`
+	ExplicitContentFilter       int    `json:"explicit_content_filter"`
+}
+
+// createServerFromTemplateParams is sent to create a server from a template, which takes the rest of the settings.
+type createServerFromTemplateParams struct {
+	Name string `json:"name"`
+	Icon string `json:"icon,omitempty"`
+}
+
+// checkServerTemplate makes sure the template exists, as Discord's error for an unknown template code is vague.
+func checkServerTemplate(ctx context.Context, m interface{}, code string) error {
+	err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/templates/%s", code), nil, nil)
+	if isDiscordError(err, discordErrorUnknownGuildTemplate) {
+		return fmt.Errorf("server template %s doesn't exist, check from_template_code", code)
 	}
-	if splash != "" {
-		edit = true
+	if err != nil {
+		return fmt.Errorf("failed to fetch server template %s: %s", code, err.Error())
 	}
 
-	afkChannel := server.AfkChannelID
-	if v, ok := d.GetOk("afk_channel_id"); ok {
-		afkChannel = disgord.ParseSnowflakeString(v.(string))
-		edit = true
+	return nil
+}
+
+// getServerCreateEdit collects the settings of a new server which the create payload doesn't take, nil if there are none.
+func getServerCreateEdit(client *disgord.Client, server *disgord.Guild, d *schema.ResourceData) (*guildExtras, diag.Diagnostics) {
+	edit := &guildExtras{}
+	hasEdit := false
+
+	// A template sets these itself, while they are part of the create payload otherwise.
+	if d.Get("from_template_code").(string) != "" {
+		verificationLevel := d.Get("verification_level").(int)
+		messageNotifications := d.Get("default_message_notifications").(int)
+		explicitContentFilter := d.Get("explicit_content_filter").(int)
+		edit.VerificationLevel = &verificationLevel
+		edit.MessageNotifications = &messageNotifications
+		edit.ExplicitContentFilter = &explicitContentFilter
+		hasEdit = true
 	}
-	afkTimeOut := server.AfkTimeout
-	if v, ok := d.GetOk("afk_timeout"); ok {
-		// The value has been already validated, so this cast is safe.
-		afkTimeOut = uint(v.(int))
-		edit = true
+
+	splash, err := getConfiguredImage(client, d, "splash")
+	if err != nil {
+		return nil, diag.Errorf("Failed to read splash: %s", err.Error())
+	}
+	if splash != "" {
+		edit.Splash = &splash
+		hasEdit = true
 	}
 
-	if edit {
-		if _, err = client.Guild(server.ID).Update(&disgord.UpdateGuild{
-			Splash:       &splash,
-			AFKChannelID: &afkChannel,
-		}); err != nil {
-			return diag.Errorf("Failed to edit server: %s", err.Error())
+	if d.HasChanges("afk_channel_id", "afk_timeout") {
+		afkChannel := d.Get("afk_channel_id").(string)
+		if diags := validateAFKChannel(client, server.ID, afkChannel); diags.HasError() {
+			return nil, diags
 		}
-		// FIXME: AFKTimeout doesn't exist in UpdateGuild struct.
-		if _, err = client.Guild(server.ID).UpdateBuilder().SetAfkTimeout(int(afkTimeOut)).Execute(); err != nil {
-			return diag.Errorf("Failed to edit server: %s", err.Error())
+		afkChannelId := nullableString(afkChannel)
+		afkTimeout := d.Get("afk_timeout").(int)
+		edit.AFKChannelID = &afkChannelId
+		edit.AFKTimeout = &afkTimeout
+		hasEdit = true
+	}
+
+	// Discord fails with "User is already owner" when the owner is sent unchanged.
+	if v, ok := d.GetOk("owner_id"); ok && getId(v.(string)) != server.OwnerID {
+		ownerId := v.(string)
+		if err := checkServerMember(client, server.ID, getId(ownerId)); err != nil {
+			return nil, diag.Errorf("Can't transfer the ownership of server %s: %s", server.ID.String(), err.Error())
 		}
+		edit.OwnerID = &ownerId
+		hasEdit = true
 	}
 
-	// Update owner's ID if the specified one is not as same as default,
-	// because we will receive "User is already owner" error if update to the same one.
-	if v, ok := d.GetOk("owner_id"); ok {
-		newOwnerId := disgord.ParseSnowflakeString(v.(string))
+	features := server.Features
+	if _, ok := d.GetOk("features"); ok || d.Get("invites_disabled").(bool) {
+		enabled := make([]string, 0)
+		for _, f := range d.Get("features").(*schema.Set).List() {
+			enabled = append(enabled, f.(string))
+		}
+		if d.Get("invites_disabled").(bool) {
+			enabled = append(enabled, serverFeatureInvitesDisabled)
+		}
+		features = mergeServerFeatures(server.Features, enabled)
+		edit.Features = &features
+		hasEdit = true
+	}
 
-		if server.OwnerID != newOwnerId {
-			if _, err = client.Guild(server.ID).Update(&disgord.UpdateGuild{
-				OwnerID: &newOwnerId,
-			}); err != nil {
-				return diag.Errorf("Failed to edit server: %s", err.Error())
-			}
+	if v, ok := d.GetOk("safety_alerts_channel_id"); ok {
+		if diags := validateSafetyAlertsChannel(server.ID, features, v.(string)); diags.HasError() {
+			return nil, diags
 		}
+		safetyAlertsChannelId := nullableString(v.(string))
+		edit.SafetyAlertsChannelID = &safetyAlertsChannelId
+		hasEdit = true
 	}
 
-	d.SetId(server.ID.String())
-	if _, ok := d.GetOk("owner_id"); !ok {
-		d.Set("owner", server.OwnerID.String())
+	if !hasEdit {
+		return nil, nil
 	}
-	d.Set("icon_hash", server.Icon)
-	d.Set("splash_hash", server.Splash)
 
-	return diags
+	return edit, nil
+}
+
+// getServerCreateEditError reports a failed create edit like updateServerFeatures and updateInvitesDisabled would,
+// as features Discord refuses are the likely cause when they are part of the edit.
+func getServerCreateEditError(serverId disgord.Snowflake, edit *guildExtras, err error) diag.Diagnostics {
+	if edit.Features == nil {
+		return diag.Errorf("Failed to edit server %s: %s", serverId.String(), err.Error())
+	}
+
+	enabled := getConfigurableServerFeatures(*edit.Features)
+	if contains(*edit.Features, serverFeatureInvitesDisabled) {
+		return diag.Errorf("Failed to edit server %s and pause its invites, Discord rejected %v or the %s feature: %s",
+			serverId.String(), enabled, serverFeatureInvitesDisabled, err.Error())
+	}
+
+	return diag.Errorf("Failed to edit features of server %s, Discord rejected %v: %s", serverId.String(), enabled, err.Error())
+}
+
+// createServer creates the server, retrying the errors which may go away by themselves.
+// Discord strictly limits the creation of servers by bots, which no retry can help with.
+func createServer(ctx context.Context, m interface{}, path string, params interface{}) (*disgord.Guild, diag.Diagnostics) {
+	delay := createServerRetryDelay
+	for attempt := 0; ; attempt++ {
+		var server disgord.Guild
+		err := discordRequest(ctx, m, http.MethodPost, path, params, &server)
+		if err == nil {
+			return &server, nil
+		}
+
+		if isDiscordError(err, discordErrorMaxServers) {
+			return nil, diag.Errorf("Failed to create server: the bot reached Discord's limit of servers it may be in, "+
+				"and bots in 10 or more servers can't create servers at all. Leave servers or use discord_managed_server instead: %s", err.Error())
+		}
+
+		// Creating a server isn't idempotent, a failed response may still have created it, so only rate limits are retried.
+		if discordErrorStatus(err) != http.StatusTooManyRequests || attempt >= createServerRetries {
+			return nil, diag.Errorf("Failed to create server: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, diag.Errorf("Failed to create server: %s", err.Error())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
 }
 
 func resourceServerManagedCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -272,11 +593,47 @@ func resourceServerManagedCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("Error: server_id must be a string")
 	}
 
+	// Checked before the ID is set, so a typo doesn't leave a tainted server in the state.
+	if d.Get("verify_exists").(bool) {
+		if _, err := m.(*Context).Client.Guild(getId(serverId)).Get(); err != nil {
+			if isDiscordError(err, discordErrorUnknownGuild, discordErrorMissingAccess) {
+				return diag.Errorf("Server %s doesn't exist or the bot isn't a member of it. Check server_id and invite the bot: %s", serverId, err.Error())
+			}
+			return diag.Errorf("Failed to fetch server %s: %s", serverId, err.Error())
+		}
+	}
+
 	d.SetId(serverId)
 
+	if diags := reconcileServerChannels(ctx, m, getId(serverId), d); diags.HasError() {
+		return diags
+	}
+
+	// Nothing else is created for a managed server, but its current settings are tracked as the baseline.
+	diags = append(diags, resourceServerManagedRead(ctx, d, m)...)
+
 	return diags
 }
 
+func resourceServerManagedRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceServerRead(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, readServerChannels(m.(*Context).Client, d)...)
+}
+
+func resourceServerManagedUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("channel") {
+		if diags := reconcileServerChannels(ctx, m, getId(d.Id()), d); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceServerUpdate(ctx, d, m)
+}
+
 func resourceServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
@@ -286,25 +643,161 @@ func resourceServerRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("Error fetching server: %s", err.Error())
 	}
 
+	setServerData(d, server)
+
+	_, botIsOwner, err := isBotOwner(client, server)
+	if err != nil {
+		return diag.Errorf("Error fetching bot user: %s", err.Error())
+	}
+	d.Set("bot_is_owner", botIsOwner)
+
+	extras, err := getGuildExtras(ctx, m, server.ID)
+	if err != nil {
+		return diag.Errorf("Error fetching server: %s", err.Error())
+	}
+	setServerExtrasData(d, extras)
+
+	return diags
+}
+
+func setServerExtrasData(d *schema.ResourceData, extras *guildExtras) {
+	if extras.NSFWLevel != nil {
+		d.Set("nsfw_level", *extras.NSFWLevel)
+	} else {
+		d.Set("nsfw_level", 0)
+	}
+	if extras.SafetyAlertsChannelID != nil {
+		d.Set("safety_alerts_channel_id", string(*extras.SafetyAlertsChannelID))
+	} else {
+		d.Set("safety_alerts_channel_id", "")
+	}
+	if extras.MaxMembers != nil {
+		d.Set("max_members", *extras.MaxMembers)
+	}
+	if extras.MaxPresences != nil {
+		d.Set("max_presences", *extras.MaxPresences)
+	}
+}
+
+// validateSafetyAlertsChannel makes sure a safety alerts channel is only set when the features of the server include
+// COMMUNITY. An empty channel clears it, which is always possible.
+func validateSafetyAlertsChannel(serverId disgord.Snowflake, features []string, channelId string) diag.Diagnostics {
+	if channelId != "" && !contains(features, "COMMUNITY") {
+		return diag.Errorf("safety_alerts_channel_id can only be set on community servers, server %s doesn't have the COMMUNITY feature", serverId.String())
+	}
+
+	return nil
+}
+
+// validateAFKChannel makes sure the AFK channel is a voice channel of the server. An empty channel removes it.
+func validateAFKChannel(client *disgord.Client, serverId disgord.Snowflake, channelId string) diag.Diagnostics {
+	if channelId == "" {
+		return nil
+	}
+
+	return validateServerChannel(client, serverId, getId(channelId), "afk_channel_id", "voice")
+}
+
+// updateSafetyAlertsChannel sets the channel for Discord's safety notifications, which is only available to community servers.
+// Discord itself rejects channels which aren't text or news channels of the server, so the channel isn't fetched first.
+func updateSafetyAlertsChannel(ctx context.Context, m interface{}, server *disgord.Guild, channelId string) diag.Diagnostics {
+	if diags := validateSafetyAlertsChannel(server.ID, server.Features, channelId); diags.HasError() {
+		return diags
+	}
+
+	safetyAlertsChannelId := nullableString(channelId)
+	if err := updateGuildExtras(ctx, m, server.ID, &guildExtras{SafetyAlertsChannelID: &safetyAlertsChannelId}); err != nil {
+		return diag.Errorf("Failed to edit safety alerts channel: %s", err.Error())
+	}
+
+	return nil
+}
+
+// updateAFKSettings sends the AFK channel and timeout in one edit, so clearing both resets the server consistently.
+// An empty channel is sent as null, which removes the AFK channel.
+func updateAFKSettings(ctx context.Context, m interface{}, serverId disgord.Snowflake, d *schema.ResourceData) diag.Diagnostics {
+	afkChannel := d.Get("afk_channel_id").(string)
+	if diags := validateAFKChannel(m.(*Context).Client, serverId, afkChannel); diags.HasError() {
+		return diags
+	}
+
+	afkChannelId := nullableString(afkChannel)
+	afkTimeout := d.Get("afk_timeout").(int)
+	if err := updateGuildExtras(ctx, m, serverId, &guildExtras{AFKChannelID: &afkChannelId, AFKTimeout: &afkTimeout}); err != nil {
+		return diag.Errorf("Failed to edit AFK settings of server %s: %s", serverId.String(), err.Error())
+	}
+
+	return nil
+}
+
+// updateInvitesDisabled pauses or resumes the invites of the server through the INVITES_DISABLED feature.
+func updateInvitesDisabled(ctx context.Context, m interface{}, server *disgord.Guild, disabled bool) diag.Diagnostics {
+	if err := setServerFeature(ctx, m, server, serverFeatureInvitesDisabled, disabled); err != nil {
+		action := map[bool]string{true: "pause", false: "resume"}[disabled]
+		return diag.Errorf("Failed to %s invites of server %s, Discord rejected the %s feature: %s", action, server.ID.String(), serverFeatureInvitesDisabled, err.Error())
+	}
+
+	return nil
+}
+
+// updateServerFeatures enables exactly the configured features out of those admins may toggle.
+func updateServerFeatures(ctx context.Context, m interface{}, server *disgord.Guild, d *schema.ResourceData) diag.Diagnostics {
+	enabled := make([]string, 0)
+	for _, f := range d.Get("features").(*schema.Set).List() {
+		enabled = append(enabled, f.(string))
+	}
+
+	if err := setServerFeatures(ctx, m, server, enabled); err != nil {
+		return diag.Errorf("Failed to edit features of server %s, Discord rejected %v: %s", server.ID.String(), enabled, err.Error())
+	}
+
+	return nil
+}
+
+func setServerData(d *schema.ResourceData, server *disgord.Guild) {
+	d.Set("server_id", server.ID.String())
 	d.Set("name", server.Name)
-	d.Set("region", server.Region)
+	// Discord dropped server regions, so an empty one keeps whatever is configured instead of diffing.
+	if region := normalizeServerRegion(server.Region); region != "" {
+		d.Set("region", region)
+	}
 	d.Set("default_message_notifications", server.DefaultMessageNotifications)
 	d.Set("afk_timeout", server.AfkTimeout)
 	d.Set("icon_hash", server.Icon)
 	d.Set("splash_hash", server.Splash)
 	d.Set("verification_level", server.VerificationLevel)
-	d.Set("default_message_notifications", server.DefaultMessageNotifications)
 	d.Set("explicit_content_filter", server.ExplicitContentFilter)
+	d.Set("created_at", getSnowflakeTime(server.ID))
+	d.Set("invites_disabled", contains(server.Features, serverFeatureInvitesDisabled))
+	d.Set("features", getConfigurableServerFeatures(server.Features))
+	d.Set("all_features", server.Features)
 	if !server.AfkChannelID.IsZero() {
 		d.Set("afk_channel_id", server.AfkChannelID.String())
+	} else {
+		d.Set("afk_channel_id", "")
 	}
 
-	// We don't want to set the owner to null, should only change this if its changing to something else
-	if d.Get("owner_id").(string) != "" && !server.OwnerID.IsZero() {
+	if !server.OwnerID.IsZero() {
 		d.Set("owner_id", server.OwnerID.String())
 	}
+}
 
-	return diags
+// validateServerChannel makes sure the channel set in key is a channel of the given type in the server,
+// as Discord doesn't report a meaningful error otherwise.
+func validateServerChannel(client *disgord.Client, serverId disgord.Snowflake, channelId disgord.Snowflake, key string, channelType string) diag.Diagnostics {
+	channel, err := client.Channel(channelId).Get()
+	if err != nil {
+		return diag.Errorf("Failed to fetch %s %s: %s", key, channelId.String(), err.Error())
+	}
+
+	if channel.GuildID != serverId {
+		return diag.Errorf("%s %s must be a channel of server %s", key, channelId.String(), serverId.String())
+	}
+	if t, _ := getTextChannelType(channel.Type); t != channelType {
+		return diag.Errorf("%s %s must be a %s channel, got a %s channel", key, channelId.String(), channelType, t)
+	}
+
+	return nil
 }
 
 func toString(v interface{}) string {
@@ -320,39 +813,40 @@ func resourceServerUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.Errorf("Error fetching server: %s", err.Error())
 	}
 
+	// Checked before any change is made, so a new owner who isn't a member doesn't leave the server half updated.
+	if v, ok := d.GetOk("owner_id"); ok && d.HasChange("owner_id") && getId(v.(string)) != server.OwnerID {
+		if err := checkServerMember(client, server.ID, getId(v.(string))); err != nil {
+			return diag.Errorf("Can't transfer the ownership of server %s: %s", server.ID.String(), err.Error())
+		}
+	}
+
 	// FIXME: Update()に書き換え
 	builder := client.Guild(server.ID).UpdateBuilder()
 	edit := false
 
-	if d.HasChange("icon_url") {
-		builder.SetIcon(imgbase64.FromRemote(d.Get("icon_url").(string)))
-		edit = true
-	}
-	if d.HasChange("icon_data_uri") {
-		builder.SetIcon(d.Get("icon_data_uri").(string))
-		edit = true
-	}
-	if d.HasChange("splash_url") {
-		builder.SetIcon(imgbase64.FromRemote(d.Get("splash_url").(string)))
-		edit = true
-	}
-	if d.HasChange("splash_data_uri") {
-		builder.SetIcon(d.Get("splash_data_uri").(string))
-		edit = true
-	}
-	if d.HasChange("afk_channel_id") {
-		builder.SetAfkChannelID(disgord.ParseSnowflakeString(d.Get("afk_channel_id").(string)))
+	if hasImageChange(d, "icon") {
+		icon, err := getConfiguredImage(client, d, "icon")
+		if err != nil {
+			return diag.Errorf("Failed to read icon: %s", err.Error())
+		}
+		if err := checkAnimatedIcon(icon, server.Features); err != nil {
+			return diag.FromErr(err)
+		}
+		builder.SetIcon(icon)
 		edit = true
 	}
-	if d.HasChange("afk_timeout") {
-		builder.SetAfkTimeout(d.Get("afk_timeout").(int))
-		edit = true
+	if hasImageChange(d, "splash") {
+		splash, err := getConfiguredImage(client, d, "splash")
+		if err != nil {
+			return diag.Errorf("Failed to read splash: %s", err.Error())
+		}
+		if _, err := client.Guild(server.ID).Update(&disgord.UpdateGuild{
+			Splash: &splash,
+		}); err != nil {
+			return diag.Errorf("Failed to edit server: %s", err.Error())
+		}
 	}
 
-	if d.HasChange("owner_id") {
-		builder.SetOwnerID(disgord.ParseSnowflakeString(d.Get("owner_id").(string)))
-		edit = true
-	}
 	if d.HasChange("verification_level") {
 		builder.SetVerificationLevel(d.Get("verification_level").(int))
 		edit = true
@@ -375,25 +869,50 @@ func resourceServerUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		edit = true
 	}
 
+	// The ownership is transferred on its own after the other changes, as it may need the MFA code. An unchanged
+	// owner isn't sent, Discord fails with "User is already owner" then.
 	ownerId, hasOwner := d.GetOk("owner_id")
-	if d.HasChange("owner_id") {
-		if hasOwner {
-			builder.SetOwnerID(getId(ownerId.(string)))
-			edit = true
-		}
-	} else {
-		if hasOwner {
-			builder.SetOwnerID(server.OwnerID)
-			edit = true
-		}
+
+	if d.HasChange("verification_level") && d.Get("verification_level").(int) == 0 && contains(server.Features, "COMMUNITY") {
+		return diag.Errorf("verification_level must be at least 1 on community servers, server %s has the COMMUNITY feature", server.ID.String())
 	}
 
 	if edit {
 		if _, err = builder.Execute(); err != nil {
+			if d.HasChange("verification_level") {
+				return diag.Errorf("Failed to edit server, Discord may have rejected verification_level %d: %s", d.Get("verification_level").(int), err.Error())
+			}
 			return diag.Errorf("Failed to edit server: %s", err.Error())
 		}
 	}
 
+	if d.HasChange("owner_id") && hasOwner && getId(ownerId.(string)) != server.OwnerID {
+		if err := transferServerOwnership(ctx, m, server.ID, ownerId.(string), d.Get("mfa_code").(string)); err != nil {
+			return diag.Errorf("Failed to transfer the ownership of server %s: %s", server.ID.String(), err.Error())
+		}
+	}
+
+	if d.HasChanges("afk_channel_id", "afk_timeout") {
+		if diags := updateAFKSettings(ctx, m, server.ID, d); diags.HasError() {
+			return diags
+		}
+	}
+	if d.HasChange("safety_alerts_channel_id") {
+		if diags := updateSafetyAlertsChannel(ctx, m, server, d.Get("safety_alerts_channel_id").(string)); diags.HasError() {
+			return diags
+		}
+	}
+	if d.HasChange("features") {
+		if diags := updateServerFeatures(ctx, m, server, d); diags.HasError() {
+			return diags
+		}
+	}
+	if d.HasChange("invites_disabled") {
+		if diags := updateInvitesDisabled(ctx, m, server, d.Get("invites_disabled").(bool)); diags.HasError() {
+			return diags
+		}
+	}
+
 	return diags
 }
 
@@ -401,6 +920,11 @@ func resourceServerDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
 
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Refusing to delete server %s because deletion_protection is enabled. "+
+			"Set deletion_protection to false and apply before destroying the server", d.Id())
+	}
+
 	if err := client.Guild(getId(d.Id())).Delete(); err != nil {
 		return diag.Errorf("Failed to delete server: %s", err)
 	}
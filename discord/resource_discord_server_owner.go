@@ -0,0 +1,116 @@
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/context"
+)
+
+func resourceDiscordServerOwner() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServerOwnerCreate,
+		ReadContext:   resourceServerOwnerRead,
+		UpdateContext: resourceServerOwnerUpdate,
+		DeleteContext: resourceServerOwnerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerOwnerImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"confirm": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Must be true for the ownership to be transferred, to avoid accidental transfers.",
+			},
+		},
+	}
+}
+
+func resourceServerOwnerImport(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+	data.Set("server_id", data.Id())
+
+	return schema.ImportStatePassthroughContext(ctx, data, i)
+}
+
+func resourceServerOwnerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.SetId(getId(d.Get("server_id").(string)).String())
+
+	diags = append(diags, resourceServerOwnerUpdate(ctx, d, m)...)
+
+	return diags
+}
+
+func resourceServerOwnerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	server, err := client.Guild(getId(d.Id())).Get()
+	if err != nil {
+		return diag.Errorf("Failed to fetch server %s: %s", d.Id(), err.Error())
+	}
+
+	d.Set("owner_id", server.OwnerID.String())
+
+	return diags
+}
+
+func resourceServerOwnerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Context).Client
+
+	server, err := client.Guild(getId(d.Id())).Get()
+	if err != nil {
+		return diag.Errorf("Failed to fetch server %s: %s", d.Id(), err.Error())
+	}
+
+	// Discord fails transferring the ownership to the current owner.
+	ownerId := getId(d.Get("owner_id").(string))
+	if server.OwnerID == ownerId {
+		return diags
+	}
+
+	if !d.Get("confirm").(bool) {
+		return diag.Errorf("Transferring the ownership of server %s to %s can't be undone by the bot, set confirm to true to do it",
+			server.ID.String(), ownerId.String())
+	}
+	_, botIsOwner, err := isBotOwner(client, server)
+	if err != nil {
+		return diag.Errorf("Failed to fetch bot user: %s", err.Error())
+	}
+	if !botIsOwner {
+		return diag.Errorf("Only the owner can transfer the ownership of server %s, which the bot isn't", server.ID.String())
+	}
+
+	if err := checkServerMember(client, server.ID, ownerId); err != nil {
+		return diag.Errorf("Can't transfer the ownership of server %s: %s", server.ID.String(), err.Error())
+	}
+
+	if _, err := client.Guild(server.ID).Update(&disgord.UpdateGuild{
+		OwnerID: &ownerId,
+	}); err != nil {
+		return diag.Errorf("Failed to transfer the ownership of server %s: %s", server.ID.String(), err.Error())
+	}
+
+	return diags
+}
+
+func resourceServerOwnerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// The ownership stays with the new owner, the bot can't take it back.
+
+	return diags
+}
@@ -0,0 +1,51 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestServerOwner(t *testing.T) {
+	params := []struct {
+		ownerId   string
+		confirm   bool
+		fails     bool
+		transfers int
+	}{
+		{ownerId: "2", confirm: false, fails: false, transfers: 0},
+		{ownerId: "5", confirm: false, fails: true, transfers: 0},
+		{ownerId: "5", confirm: true, fails: false, transfers: 1},
+		// 6 isn't a member of the server.
+		{ownerId: "6", confirm: true, fails: true, transfers: 0},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1":           {{status: http.StatusOK, body: `{"id": "1", "owner_id": "2"}`}},
+			"GET /users/@me":          {{status: http.StatusOK, body: `{"id": "2"}`}},
+			"GET /guilds/1/members/5": {{status: http.StatusOK, body: `{"user": {"id": "5"}, "roles": []}`}},
+			"GET /guilds/1/members/6": {{status: http.StatusNotFound, body: `{"code": 10007, "message": "Unknown Member"}`}},
+			"PATCH /guilds/1":         {{status: http.StatusOK, body: `{"id": "1", "owner_id": "5"}`}},
+		})
+
+		d := schema.TestResourceDataRaw(t, resourceDiscordServerOwner().Schema, map[string]interface{}{
+			"server_id": "1",
+			"owner_id":  p.ownerId,
+			"confirm":   p.confirm,
+		})
+		diags := resourceServerOwnerCreate(context.Background(), d, c)
+		if diags.HasError() != p.fails {
+			t.Errorf("owner_id: %v, confirm: %v - create Error: ex: %v, ac: %v", p.ownerId, p.confirm, p.fails, diags)
+		}
+		if p.ownerId == "6" && (len(diags) == 0 || !strings.Contains(diags[0].Summary, "not a member")) {
+			t.Errorf("owner_id: %v - member Error: ex: %v, ac: %v", p.ownerId, "not a member", diags)
+		}
+		if ac := transport.count("PATCH /guilds/1"); ac != p.transfers {
+			t.Errorf("owner_id: %v, confirm: %v - transfers Error: ex: %v, ac: %v", p.ownerId, p.confirm, p.transfers, ac)
+		}
+	}
+}
@@ -0,0 +1,790 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSetServerDataDefaultMessageNotifications(t *testing.T) {
+	params := []struct {
+		state int
+		api   disgord.DefaultMessageNotificationLvl
+	}{
+		{state: 0, api: 0},
+		{state: 0, api: 1},
+		{state: 1, api: 0},
+		{state: 1, api: 1},
+	}
+
+	for _, p := range params {
+		d := schema.TestResourceDataRaw(t, serverSchema(), map[string]interface{}{
+			"name":                          "server",
+			"default_message_notifications": p.state,
+		})
+
+		setServerData(d, &disgord.Guild{Name: "server", DefaultMessageNotifications: p.api})
+
+		if ac := d.Get("default_message_notifications").(int); ac != int(p.api) {
+			t.Errorf("state: %v - default_message_notifications Error: ex: %v, ac: %v", p.state, p.api, ac)
+		}
+	}
+}
+
+func TestResourceServerCreateKeepsIdWhenEditFails(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"POST /guilds":           {{status: http.StatusCreated, body: `{"id": "1", "name": "server", "owner_id": "2"}`}},
+		"GET /guilds/1/channels": {{status: http.StatusOK, body: `[]`}},
+		"PATCH /guilds/1":        {{status: http.StatusBadRequest, body: `{"code": 50035, "message": "Invalid Form Body"}`}},
+	})
+
+	d := schema.TestResourceDataRaw(t, serverSchema(), map[string]interface{}{
+		"name":            "server",
+		"splash_data_uri": "data:image/png;base64,AAAA",
+	})
+
+	diags := resourceServerCreate(context.Background(), d, c)
+	if !diags.HasError() {
+		t.Fatalf("diags Error: ex: %v, ac: %v", "error", diags)
+	}
+	if transport.count("PATCH /guilds/1") == 0 {
+		t.Fatalf("requests Error: ex: %v, ac: %v", "PATCH /guilds/1", transport.requests)
+	}
+	if d.Id() != "1" {
+		t.Errorf("id Error: ex: %v, ac: %v", "1", d.Id())
+	}
+}
+
+func TestResourceServerCreateSingleEdit(t *testing.T) {
+	guild := `{"id": "1", "name": "server", "owner_id": "2", "features": []}`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"POST /guilds":            {{status: http.StatusCreated, body: guild}},
+		"GET /guilds/1/channels":  {{status: http.StatusOK, body: `[]`}},
+		"GET /guilds/1/members/3": {{status: http.StatusOK, body: `{"user": {"id": "3"}, "roles": []}`}},
+		"PATCH /guilds/1":         {{status: http.StatusOK, body: guild}},
+	})
+
+	d := schema.TestResourceDataRaw(t, serverSchema(), map[string]interface{}{
+		"name":             "server",
+		"splash_data_uri":  "data:image/png;base64,AAAA",
+		"afk_timeout":      900,
+		"owner_id":         "3",
+		"invites_disabled": true,
+	})
+
+	if diags := resourceServerCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := transport.count("PATCH /guilds/1"); ac != 1 {
+		t.Fatalf("edits Error: ex: %v, ac: %v", 1, ac)
+	}
+
+	for i, req := range transport.requests {
+		if req != "PATCH /guilds/1" {
+			continue
+		}
+		for _, expected := range []string{`"splash":"data:image/png;base64,AAAA"`, `"afk_timeout":900`, `"owner_id":"3"`, `"features":["INVITES_DISABLED"]`} {
+			if !strings.Contains(transport.bodies[i], expected) {
+				t.Errorf("payload Error: ex: %v, ac: %v", expected, transport.bodies[i])
+			}
+		}
+	}
+}
+
+func TestResourceServerCreateEditFeatureErrors(t *testing.T) {
+	params := []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{config: map[string]interface{}{"splash_data_uri": "data:image/png;base64,AAAA"}, expected: "Failed to edit server 1: "},
+		{config: map[string]interface{}{"features": []interface{}{"COMMUNITY"}}, expected: "Discord rejected [COMMUNITY]: "},
+		{config: map[string]interface{}{"invites_disabled": true}, expected: "pause its invites, Discord rejected [] or the INVITES_DISABLED feature: "},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"POST /guilds":           {{status: http.StatusCreated, body: `{"id": "1", "name": "server", "owner_id": "2", "features": []}`}},
+			"GET /guilds/1/channels": {{status: http.StatusOK, body: `[]`}},
+			"PATCH /guilds/1":        {{status: http.StatusBadRequest, body: `{"code": 50035, "message": "Invalid Form Body"}`}},
+		})
+
+		p.config["name"] = "server"
+		d := schema.TestResourceDataRaw(t, serverSchema(), p.config)
+
+		diags := resourceServerCreate(context.Background(), d, c)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, p.expected) {
+			t.Errorf("config: %v - diags Error: ex: %v, ac: %v", p.config, p.expected, diags)
+		}
+	}
+}
+
+func TestResourceServerCreateFromTemplate(t *testing.T) {
+	guild := `{"id": "1", "name": "server", "owner_id": "2", "features": []}`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/templates/abc":  {{status: http.StatusOK, body: `{"code": "abc", "name": "blueprint"}`}},
+		"POST /guilds/templates/abc": {{status: http.StatusCreated, body: guild}},
+		"PATCH /guilds/1":            {{status: http.StatusOK, body: guild}},
+	})
+
+	d := schema.TestResourceDataRaw(t, serverSchema(), map[string]interface{}{
+		"name":               "server",
+		"from_template_code": "abc",
+		"verification_level": 2,
+	})
+
+	if diags := resourceServerCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := transport.count("POST /guilds"); ac != 0 {
+		t.Errorf("create payload Error: ex: %v, ac: %v", 0, ac)
+	}
+	if ac := transport.count("GET /guilds/1/channels"); ac != 0 {
+		t.Errorf("template channels Error: ex: %v, ac: %v", "kept", ac)
+	}
+	for i, req := range transport.requests {
+		if req == "PATCH /guilds/1" && !strings.Contains(transport.bodies[i], `"verification_level":2`) {
+			t.Errorf("payload Error: ex: %v, ac: %v", `"verification_level":2`, transport.bodies[i])
+		}
+	}
+
+	c, transport = newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/templates/abc": {{status: http.StatusNotFound, body: `{"code": 10057, "message": "Unknown Guild Template"}`}},
+	})
+	d = schema.TestResourceDataRaw(t, serverSchema(), map[string]interface{}{
+		"name":               "server",
+		"from_template_code": "abc",
+	})
+
+	diags := resourceServerCreate(context.Background(), d, c)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "doesn't exist") {
+		t.Errorf("unknown template Error: ex: %v, ac: %v", "template doesn't exist", diags)
+	}
+	if ac := transport.count("POST /guilds/templates/abc"); ac != 0 {
+		t.Errorf("unknown template requests Error: ex: %v, ac: %v", 0, ac)
+	}
+}
+
+func TestUpdateInvitesDisabledKeepsOtherFeatures(t *testing.T) {
+	params := []struct {
+		features []string
+		disabled bool
+		payload  string
+	}{
+		{features: []string{"COMMUNITY"}, disabled: true, payload: `{"features":["COMMUNITY","INVITES_DISABLED"]}`},
+		{features: []string{"INVITES_DISABLED", "COMMUNITY"}, disabled: false, payload: `{"features":["COMMUNITY"]}`},
+		{features: []string{"INVITES_DISABLED"}, disabled: false, payload: `{"features":[]}`},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"PATCH /guilds/1": {{status: http.StatusOK, body: `{"id": "1"}`}},
+		})
+
+		diags := updateInvitesDisabled(context.Background(), c, &disgord.Guild{ID: 1, Features: p.features}, p.disabled)
+		if diags.HasError() {
+			t.Fatalf("features: %v - diags Error: ex: %v, ac: %v", p.features, nil, diags)
+		}
+		if ac := transport.bodies[len(transport.bodies)-1]; ac != p.payload {
+			t.Errorf("features: %v - payload Error: ex: %v, ac: %v", p.features, p.payload, ac)
+		}
+	}
+}
+
+func TestUpdateSafetyAlertsChannel(t *testing.T) {
+	params := []struct {
+		features []string
+		err      bool
+	}{
+		{features: []string{"COMMUNITY"}, err: false},
+		{features: []string{}, err: true},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"PATCH /guilds/1": {{status: http.StatusOK, body: `{"id": "1"}`}},
+		})
+
+		// Channel 7 may be a news channel, Discord checks the type itself so it isn't fetched.
+		diags := updateSafetyAlertsChannel(context.Background(), c, &disgord.Guild{ID: 1, Features: p.features}, "7")
+		if diags.HasError() != p.err {
+			t.Fatalf("features: %v - diags Error: ex: %v, ac: %v", p.features, p.err, diags)
+		}
+		if ac := transport.count("GET /channels/7"); ac != 0 {
+			t.Errorf("features: %v - requests Error: ex: %v, ac: %v", p.features, 0, ac)
+		}
+		if p.err {
+			continue
+		}
+		if ac := transport.bodies[len(transport.bodies)-1]; ac != `{"safety_alerts_channel_id":"7"}` {
+			t.Errorf("features: %v - payload Error: ex: %v, ac: %v", p.features, `{"safety_alerts_channel_id":"7"}`, ac)
+		}
+	}
+}
+
+func TestSetServerFeaturesKeepsGrantedFeatures(t *testing.T) {
+	params := []struct {
+		features []string
+		enabled  []string
+		payload  string
+	}{
+		{features: []string{"PARTNERED", "INVITES_DISABLED"}, enabled: []string{"COMMUNITY"}, payload: `{"features":["PARTNERED","INVITES_DISABLED","COMMUNITY"]}`},
+		{features: []string{"COMMUNITY", "DISCOVERABLE", "VANITY_URL"}, enabled: []string{"COMMUNITY"}, payload: `{"features":["VANITY_URL","COMMUNITY"]}`},
+		{features: []string{"RAID_ALERTS_DISABLED"}, enabled: []string{}, payload: `{"features":[]}`},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"PATCH /guilds/1": {{status: http.StatusOK, body: `{"id": "1"}`}},
+		})
+
+		server := &disgord.Guild{ID: 1, Features: p.features}
+		if err := setServerFeatures(context.Background(), c, server, p.enabled); err != nil {
+			t.Fatalf("features: %v - err Error: ex: %v, ac: %v", p.features, nil, err)
+		}
+		if ac := transport.bodies[len(transport.bodies)-1]; ac != p.payload {
+			t.Errorf("features: %v - payload Error: ex: %v, ac: %v", p.features, p.payload, ac)
+		}
+		if ac := getConfigurableServerFeatures(server.Features); len(ac) != len(p.enabled) {
+			t.Errorf("features: %v - configurable Error: ex: %v, ac: %v", p.features, p.enabled, ac)
+		}
+	}
+}
+
+// testRawConfig converts a configuration into the value Terraform sends along with a plan, which GetRawConfig returns.
+func testRawConfig(t *testing.T, r *schema.Resource, config map[string]interface{}) cty.Value {
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("config Error: ex: %v, ac: %v", nil, err)
+	}
+	v, err := ctyjson.Unmarshal(b, schema.InternalMap(r.Schema).CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("config Error: ex: %v, ac: %v", nil, err)
+	}
+
+	return v
+}
+
+func TestResourceServerFeaturesPlanRemoval(t *testing.T) {
+	params := []struct {
+		features []interface{}
+		diff     bool
+	}{
+		{features: nil, diff: false},
+		{features: []interface{}{}, diff: true},
+		{features: []interface{}{"COMMUNITY"}, diff: false},
+	}
+
+	for _, p := range params {
+		r := resourceDiscordServer()
+		state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{
+			"id": "1", "name": "server", "afk_timeout": "300", "features.#": "1",
+			fmt.Sprintf("features.%d", schema.HashString("COMMUNITY")): "COMMUNITY",
+		}}
+		config := map[string]interface{}{"name": "server"}
+		if p.features != nil {
+			config["features"] = p.features
+		}
+		state.RawConfig = testRawConfig(t, r, config)
+
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Fatalf("features: %v - diff Error: ex: %v, ac: %v", p.features, nil, err)
+		}
+		ac := diff != nil && diff.Attributes["features.#"] != nil
+		if ac != p.diff {
+			t.Errorf("features: %v - plan Error: ex: %v, ac: %v", p.features, p.diff, diff)
+		}
+	}
+}
+
+func TestCreateServerRetries(t *testing.T) {
+	delay := createServerRetryDelay
+	t.Cleanup(func() { createServerRetryDelay = delay })
+	createServerRetryDelay = time.Millisecond
+
+	params := []struct {
+		responses []mockResponse
+		err       bool
+		requests  int
+	}{
+		{
+			responses: []mockResponse{
+				{status: http.StatusTooManyRequests, body: `{"message": "You are being rate limited.", "retry_after": 0}`},
+				{status: http.StatusCreated, body: `{"id": "1", "name": "server"}`},
+			},
+			err:      false,
+			requests: 2,
+		},
+		// The server may have been created despite the error, retrying could create it twice.
+		{
+			responses: []mockResponse{
+				{status: http.StatusBadGateway, body: `{"code": 0, "message": "502: Bad Gateway"}`},
+				{status: http.StatusCreated, body: `{"id": "1", "name": "server"}`},
+			},
+			err:      true,
+			requests: 1,
+		},
+		{
+			responses: []mockResponse{{status: http.StatusBadRequest, body: `{"code": 30001, "message": "Maximum number of guilds reached (100)"}`}},
+			err:       true,
+			requests:  1,
+		},
+		{
+			responses: []mockResponse{{status: http.StatusBadRequest, body: `{"code": 50035, "message": "Invalid Form Body"}`}},
+			err:       true,
+			requests:  1,
+		},
+	}
+
+	for i, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{"POST /guilds": p.responses})
+
+		_, diags := createServer(context.Background(), c, "/guilds", &createServerParams{Name: "server"})
+		if diags.HasError() != p.err {
+			t.Errorf("case: %v - error Error: ex: %v, ac: %v", i, p.err, diags)
+		}
+		if ac := transport.count("POST /guilds"); ac != p.requests {
+			t.Errorf("case: %v - requests Error: ex: %v, ac: %v", i, p.requests, ac)
+		}
+	}
+}
+
+func TestResourceServerWithoutRegionPlansClean(t *testing.T) {
+	params := []map[string]interface{}{
+		{"name": "server"},
+		{"name": "server", "region": "us-west"},
+	}
+
+	for _, config := range params {
+		guild := `{"id": "1", "name": "server", "owner_id": "2", "region": null, "afk_timeout": 300}`
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"POST /guilds":           {{status: http.StatusCreated, body: guild}},
+			"GET /guilds/1":          {{status: http.StatusOK, body: guild}},
+			"GET /users/@me":         {{status: http.StatusOK, body: `{"id": "2"}`}},
+			"GET /guilds/1/channels": {{status: http.StatusOK, body: `[]`}},
+			"PATCH /guilds/1":        {{status: http.StatusOK, body: guild}},
+		})
+
+		r := resourceDiscordServer()
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+		if diags := resourceServerCreate(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("config: %v - create Error: ex: %v, ac: %v", config, nil, diags)
+		}
+
+		_, hasRegion := config["region"]
+		for i, req := range transport.requests {
+			if req == "POST /guilds" && strings.Contains(transport.bodies[i], `"region"`) != hasRegion {
+				t.Errorf("config: %v - payload Error: ex: region sent %v, ac: %v", config, hasRegion, transport.bodies[i])
+			}
+		}
+
+		if diags := resourceServerRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("config: %v - read Error: ex: %v, ac: %v", config, nil, diags)
+		}
+		if !d.Get("bot_is_owner").(bool) {
+			t.Errorf("config: %v - bot_is_owner Error: ex: %v, ac: %v", config, true, false)
+		}
+
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+		if err != nil {
+			t.Fatalf("config: %v - diff Error: ex: %v, ac: %v", config, nil, err)
+		}
+		if diff != nil && len(diff.Attributes) > 0 {
+			t.Errorf("config: %v - plan Error: ex: %v, ac: %v", config, "no changes", diff.Attributes)
+		}
+	}
+}
+
+func TestResourceServerImportPlansClean(t *testing.T) {
+	guild := `{"id": "1", "name": "server", "owner_id": "3", "region": null, "afk_timeout": 300,
+		"icon": "abc", "splash": "def", "features": ["COMMUNITY", "NEWS"], "nsfw_level": 3}`
+	c, _ := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1":  {{status: http.StatusOK, body: guild}},
+		"GET /users/@me": {{status: http.StatusOK, body: `{"id": "2"}`}},
+	})
+
+	r := resourceDiscordServer()
+	imported, err := r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: "1"}), c)
+	if err != nil || len(imported) != 1 {
+		t.Fatalf("import Error: ex: %v, ac: %v", nil, err)
+	}
+	d := imported[0]
+	if diags := resourceServerRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	expected := map[string]string{"server_id": "1", "owner_id": "3", "icon_hash": "abc", "splash_hash": "def", "features.#": "1", "nsfw_level": "3"}
+	for k, v := range expected {
+		if ac := d.State().Attributes[k]; ac != v {
+			t.Errorf("%s Error: ex: %v, ac: %v", k, v, ac)
+		}
+	}
+
+	config := map[string]interface{}{"name": "server", "features": []interface{}{"COMMUNITY"}}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatalf("diff Error: ex: %v, ac: %v", nil, err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("plan Error: ex: %v, ac: %v", "no changes", diff.Attributes)
+	}
+}
+
+func TestResourceServerRegionDrift(t *testing.T) {
+	params := []struct {
+		region   string
+		reported string
+	}{
+		{region: "US-West", reported: "us-west"},
+		{region: "us-west", reported: "deprecated"},
+		{region: "europe", reported: "Europe"},
+	}
+
+	for _, p := range params {
+		guild := fmt.Sprintf(`{"id": "1", "name": "server", "owner_id": "2", "region": "%s", "afk_timeout": 300}`, p.reported)
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1":  {{status: http.StatusOK, body: guild}},
+			"GET /users/@me": {{status: http.StatusOK, body: `{"id": "2"}`}},
+		})
+
+		r := resourceDiscordServer()
+		config := map[string]interface{}{"name": "server", "region": p.region}
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+		d.SetId("1")
+		if diags := resourceServerRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("region: %v - read Error: ex: %v, ac: %v", p.region, nil, diags)
+		}
+
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+		if err != nil {
+			t.Fatalf("region: %v - diff Error: ex: %v, ac: %v", p.region, nil, err)
+		}
+		if diff != nil && len(diff.Attributes) > 0 {
+			t.Errorf("region: %v, reported: %v - plan Error: ex: %v, ac: %v", p.region, p.reported, "no changes", diff.Attributes)
+		}
+	}
+}
+
+func TestResourceServerDeletionProtection(t *testing.T) {
+	params := []struct {
+		protected bool
+		requests  int
+	}{
+		{protected: true, requests: 0},
+		{protected: false, requests: 1},
+	}
+
+	for _, p := range params {
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"DELETE /guilds/1": {{status: http.StatusNoContent}},
+		})
+
+		d := schema.TestResourceDataRaw(t, serverSchema(), map[string]interface{}{
+			"name":                "server",
+			"deletion_protection": p.protected,
+		})
+		d.SetId("1")
+
+		diags := resourceServerDelete(context.Background(), d, c)
+		if diags.HasError() != p.protected {
+			t.Errorf("protected: %v - diags Error: ex: %v, ac: %v", p.protected, p.protected, diags)
+		}
+		if ac := transport.count("DELETE /guilds/1"); ac != p.requests {
+			t.Errorf("protected: %v - requests Error: ex: %v, ac: %v", p.protected, p.requests, ac)
+		}
+	}
+}
+
+func TestManagedServerChannels(t *testing.T) {
+	guild := `{"id": "1", "name": "server", "owner_id": "2"}`
+	category := `{"id": "20", "guild_id": "1", "type": 4, "name": "info"}`
+	rules := `{"id": "21", "guild_id": "1", "type": 0, "name": "rules", "parent_id": "20"}`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1":           {{status: http.StatusOK, body: guild}},
+		"GET /users/@me":          {{status: http.StatusOK, body: `{"id": "2"}`}},
+		"POST /guilds/1/channels": {{status: http.StatusCreated, body: category}, {status: http.StatusCreated, body: rules}},
+		"GET /channels/20":        {{status: http.StatusOK, body: category}},
+		"GET /channels/21":        {{status: http.StatusOK, body: rules}},
+		"DELETE /channels/21":     {{status: http.StatusOK, body: rules}},
+	})
+
+	r := resourceDiscordManagedServer()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id": "1",
+		"channel": []interface{}{
+			map[string]interface{}{"name": "rules", "category": "info"},
+			map[string]interface{}{"name": "info", "type": "category"},
+		},
+	})
+
+	if diags := resourceServerManagedCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	var bodies []string
+	for i, req := range transport.requests {
+		if req == "POST /guilds/1/channels" {
+			bodies = append(bodies, transport.bodies[i])
+		}
+	}
+	if len(bodies) != 2 || !strings.Contains(bodies[0], `"type":4`) || !strings.Contains(bodies[1], `"parent_id":"20"`) {
+		t.Errorf("payload Error: ex: %v, ac: %v", "category first, then rules in it", bodies)
+	}
+	if ac := d.Get("channel.0.id").(string); ac != "21" {
+		t.Errorf("channel.0.id Error: ex: %v, ac: %v", "21", ac)
+	}
+	if ac := d.Get("channel.0.category").(string); ac != "info" {
+		t.Errorf("channel.0.category Error: ex: %v, ac: %v", "info", ac)
+	}
+
+	// Dropping a channel from the block deletes only that channel.
+	d = testResourceDataDiff(t, r, d.State(), map[string]interface{}{
+		"server_id": "1",
+		"channel": []interface{}{
+			map[string]interface{}{"name": "info", "type": "category"},
+		},
+	}, c)
+	if diags := reconcileServerChannels(context.Background(), c, 1, d); diags.HasError() {
+		t.Fatalf("update Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := transport.count("DELETE /channels/21"); ac != 1 {
+		t.Errorf("requests Error: ex: %v, ac: %v", 1, ac)
+	}
+	if ac := transport.count("DELETE /channels/20"); ac != 0 {
+		t.Errorf("requests Error: ex: %v, ac: %v", 0, ac)
+	}
+}
+
+func TestManagedServerChannelsPartialState(t *testing.T) {
+	category := `{"id": "20", "guild_id": "1", "type": 4, "name": "info"}`
+	c, _ := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1": {{status: http.StatusOK, body: `{"id": "1", "name": "server", "owner_id": "2"}`}},
+		"POST /guilds/1/channels": {
+			{status: http.StatusCreated, body: category},
+			{status: http.StatusBadRequest, body: `{"code": 50035, "message": "Invalid Form Body"}`},
+		},
+	})
+
+	r := resourceDiscordManagedServer()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id": "1",
+		"channel": []interface{}{
+			map[string]interface{}{"name": "rules", "category": "info"},
+			map[string]interface{}{"name": "info", "type": "category"},
+		},
+	})
+
+	// The category which was created is kept, so the next apply doesn't create it again.
+	if diags := resourceServerManagedCreate(context.Background(), d, c); !diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", "an error", diags)
+	}
+	channels := d.Get("channel").([]interface{})
+	if len(channels) != 1 || channels[0].(map[string]interface{})["id"] != "20" {
+		t.Errorf("channel Error: ex: %v, ac: %v", "only the info category", channels)
+	}
+}
+
+func TestResourceServerClearAFKSettings(t *testing.T) {
+	guild := `{"id": "1", "name": "server", "owner_id": "2", "afk_channel_id": null, "afk_timeout": 300}`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1":   {{status: http.StatusOK, body: guild}},
+		"PATCH /guilds/1": {{status: http.StatusOK, body: guild}},
+		"GET /users/@me":  {{status: http.StatusOK, body: `{"id": "3"}`}},
+	})
+
+	r := resourceDiscordServer()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "server", "afk_channel_id": "5", "afk_timeout": 900})
+	d.SetId("1")
+	config := map[string]interface{}{"name": "server"}
+	d = testResourceDataDiff(t, r, d.State(), config, c)
+
+	if diags := resourceServerUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("update Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	var patches []string
+	for i, req := range transport.requests {
+		if req == "PATCH /guilds/1" {
+			patches = append(patches, transport.bodies[i])
+		}
+	}
+	expected := `{"afk_channel_id":null,"afk_timeout":300}`
+	if len(patches) != 1 || patches[0] != expected {
+		t.Errorf("payload Error: ex: %v, ac: %v", expected, patches)
+	}
+
+	if diags := resourceServerRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatalf("diff Error: ex: %v, ac: %v", nil, err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("plan Error: ex: %v, ac: %v", "no changes", diff.Attributes)
+	}
+}
+
+func TestManagedServerVerifyExists(t *testing.T) {
+	// Without verify_exists the server is tracked before the failing read, as it was before the option existed.
+	params := []struct {
+		verify bool
+		id     string
+	}{
+		{verify: true, id: ""},
+		{verify: false, id: "1"},
+	}
+
+	for _, p := range params {
+		c, _ := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1": {{status: http.StatusNotFound, body: `{"code": 10004, "message": "Unknown Guild"}`}},
+		})
+
+		r := resourceDiscordManagedServer()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"server_id": "1", "verify_exists": p.verify})
+		diags := resourceServerManagedCreate(context.Background(), d, c)
+		if p.verify && (!diags.HasError() || !strings.Contains(diags[0].Summary, "isn't a member")) {
+			t.Errorf("verify: %v - error Error: ex: %v, ac: %v", p.verify, "a clear error", diags)
+		}
+		if d.Id() != p.id {
+			t.Errorf("verify: %v - id Error: ex: %v, ac: %v", p.verify, p.id, d.Id())
+		}
+	}
+}
+
+func TestManagedServerName(t *testing.T) {
+	guild := `{"id": "1", "name": "server", "owner_id": "2", "afk_timeout": 300}`
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1":   {{status: http.StatusOK, body: guild}},
+		"PATCH /guilds/1": {{status: http.StatusOK, body: guild}},
+		"GET /users/@me":  {{status: http.StatusOK, body: `{"id": "3"}`}},
+	})
+
+	r := resourceDiscordManagedServer()
+	config := map[string]interface{}{"server_id": "1"}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceServerManagedCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create Error: ex: %v, ac: %v", nil, diags)
+	}
+	if ac := d.Get("name").(string); ac != "server" {
+		t.Errorf("name Error: ex: %v, ac: %v", "server", ac)
+	}
+
+	// An adopted server keeps its name as long as none is configured.
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatalf("diff Error: ex: %v, ac: %v", nil, err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("plan Error: ex: %v, ac: %v", "no changes", diff.Attributes)
+	}
+
+	d = testResourceDataDiff(t, r, d.State(), map[string]interface{}{"server_id": "1", "name": "renamed"}, c)
+	if diags := resourceServerManagedUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("update Error: ex: %v, ac: %v", nil, diags)
+	}
+
+	var patches []string
+	for i, req := range transport.requests {
+		if req == "PATCH /guilds/1" {
+			patches = append(patches, transport.bodies[i])
+		}
+	}
+	if len(patches) != 1 || !strings.Contains(patches[0], `"name":"renamed"`) {
+		t.Errorf("payload Error: ex: %v, ac: %v", `"name":"renamed"`, patches)
+	}
+}
+
+func TestValidateVerificationLevel(t *testing.T) {
+	params := []struct {
+		level  int
+		warns  int
+		errors int
+	}{
+		{level: 0, warns: 0, errors: 0},
+		{level: 2, warns: 0, errors: 0},
+		{level: 3, warns: 1, errors: 0},
+		{level: 4, warns: 1, errors: 0},
+		{level: 5, warns: 0, errors: 1},
+	}
+
+	validate := serverSchema()["verification_level"].ValidateFunc
+	for _, p := range params {
+		warns, errors := validate(p.level, "verification_level")
+		if len(warns) != p.warns {
+			t.Errorf("level: %v - warns Error: ex: %v, ac: %v", p.level, p.warns, warns)
+		}
+		if len(errors) != p.errors {
+			t.Errorf("level: %v - errors Error: ex: %v, ac: %v", p.level, p.errors, errors)
+		}
+	}
+}
+
+func TestResourceServerAnimatedIcon(t *testing.T) {
+	icon := filepath.Join(t.TempDir(), "icon.gif")
+	if err := os.WriteFile(icon, []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	params := []struct {
+		features string
+		patches  int
+		err      bool
+	}{
+		{features: `["ANIMATED_ICON"]`, patches: 1, err: false},
+		{features: `[]`, patches: 0, err: true},
+	}
+
+	for _, p := range params {
+		guild := `{"id": "1", "name": "server", "owner_id": "2", "icon": "a_1234", "features": ` + p.features + `}`
+		c, transport := newTestContext(t, map[string][]mockResponse{
+			"GET /guilds/1":   {{status: http.StatusOK, body: guild}},
+			"PATCH /guilds/1": {{status: http.StatusOK, body: guild}},
+			"GET /users/@me":  {{status: http.StatusOK, body: `{"id": "3"}`}},
+		})
+
+		r := resourceDiscordServer()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "server"})
+		d.SetId("1")
+		d = testResourceDataDiff(t, r, d.State(), map[string]interface{}{"name": "server", "icon_file": icon}, c)
+
+		diags := resourceServerUpdate(context.Background(), d, c)
+		if diags.HasError() != p.err {
+			t.Fatalf("features %s: update Error: ex: %v, ac: %v", p.features, p.err, diags)
+		}
+
+		var patches []string
+		for i, req := range transport.requests {
+			if req == "PATCH /guilds/1" {
+				patches = append(patches, transport.bodies[i])
+			}
+		}
+		if len(patches) != p.patches {
+			t.Fatalf("features %s: patches Error: ex: %v, ac: %v", p.features, p.patches, patches)
+		}
+		if p.err {
+			continue
+		}
+		if !strings.Contains(patches[0], `"icon":"data:image/gif;base64,`) {
+			t.Errorf("payload Error: ex: %v, ac: %v", "a GIF data URI", patches[0])
+		}
+		if diags := resourceServerRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("read Error: ex: %v, ac: %v", nil, diags)
+		}
+		if ac := d.Get("icon_hash").(string); ac != "a_1234" {
+			t.Errorf("icon_hash Error: ex: %v, ac: %v", "a_1234", ac)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package discord
 
 import (
+	"fmt"
+
 	"github.com/andersfylling/disgord"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,6 +29,45 @@ func resourceDiscordSystemChannel() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"system_channel_flags": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"system_channel_flag_names", "suppress_boost_notifications"},
+				ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+					v := val.(int)
+					if v < 0 || v > 15 {
+						errors = append(errors, fmt.Errorf("system_channel_flags must be between 0 and 15 inclusive, got: %d", v))
+					}
+
+					return
+				},
+			},
+			"system_channel_flag_names": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"system_channel_flags", "suppress_boost_notifications"},
+				Set:           schema.HashString,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(val interface{}, key string) (warns []string, errors []error) {
+						v := val.(string)
+						if _, ok := systemChannelFlags[v]; !ok {
+							errors = append(errors, fmt.Errorf("%s is not a valid system channel flag", v))
+						}
+
+						return
+					},
+				},
+			},
+			"suppress_boost_notifications": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"system_channel_flags", "system_channel_flag_names"},
+				Description:   "Whether the messages about new server boosts are hidden, the other flags are left as they are.",
+			},
 		},
 	}
 }
@@ -67,9 +108,73 @@ func resourceSystemChannelCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(d.Get("server_id").(string))
 
+	if diags := updateSystemChannelFlags(ctx, m, serverId, d); diags.HasError() {
+		return diags
+	}
+	diags = append(diags, checkSystemJoinMessages(ctx, m, serverId, d)...)
+
 	return diags
 }
 
+// getConfiguredSystemChannelFlags returns the flags set by either system_channel_flags or system_channel_flag_names.
+func getConfiguredSystemChannelFlags(d *schema.ResourceData) (int, bool) {
+	if d.HasChange("system_channel_flag_names") {
+		return getSystemChannelFlags(d.Get("system_channel_flag_names").(*schema.Set).List()), true
+	}
+	if d.HasChange("system_channel_flags") {
+		return d.Get("system_channel_flags").(int), true
+	}
+
+	return 0, false
+}
+
+// updateSystemChannelFlags applies the configured flags. suppress_boost_notifications only changes its own bit of the
+// current flags, and is applied whenever it's set in the configuration of a new resource, so false is applied too.
+func updateSystemChannelFlags(ctx context.Context, m interface{}, serverId disgord.Snowflake, d *schema.ResourceData) diag.Diagnostics {
+	flags, ok := getConfiguredSystemChannelFlags(d)
+
+	suppressBoosts := d.GetRawConfig().GetAttr("suppress_boost_notifications")
+	if !ok && !suppressBoosts.IsNull() && (d.IsNewResource() || d.HasChange("suppress_boost_notifications")) {
+		extras, err := getGuildExtras(ctx, m, serverId)
+		if err != nil {
+			return diag.Errorf("Error fetching server: %s", err.Error())
+		}
+		if extras.SystemChannelFlags != nil {
+			flags = *extras.SystemChannelFlags
+		}
+		flags = setSystemChannelFlag(flags, "suppress_premium_subscriptions", suppressBoosts.True())
+		ok = true
+	}
+
+	if ok {
+		if err := updateGuildExtras(ctx, m, serverId, &guildExtras{SystemChannelFlags: &flags}); err != nil {
+			return diag.Errorf("Failed to edit system channel flags: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// checkSystemJoinMessages warns about join messages without a system channel. Terraform can't show warnings while
+// planning, so this is only reported once the change is applied.
+func checkSystemJoinMessages(ctx context.Context, m interface{}, serverId disgord.Snowflake, d *schema.ResourceData) diag.Diagnostics {
+	channelId := disgord.ParseSnowflakeString(d.Get("system_channel_id").(string))
+	if !channelId.IsZero() {
+		return nil
+	}
+
+	// The change is already applied, so flags which can't be fetched only leave out the warning.
+	extras, err := getGuildExtras(ctx, m, serverId)
+	if err != nil {
+		return nil
+	}
+	if extras.SystemChannelFlags == nil {
+		return warnSystemJoinMessages(channelId, 0)
+	}
+
+	return warnSystemJoinMessages(channelId, *extras.SystemChannelFlags)
+}
+
 func resourceSystemChannelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Context).Client
@@ -83,6 +188,16 @@ func resourceSystemChannelRead(ctx context.Context, d *schema.ResourceData, m in
 
 	d.Set("system_channel_id", server.SystemChannelID.String())
 
+	extras, err := getGuildExtras(ctx, m, serverId)
+	if err != nil {
+		return diag.Errorf("Error fetching server: %s", err.Error())
+	}
+	if extras.SystemChannelFlags != nil {
+		d.Set("system_channel_flags", *extras.SystemChannelFlags)
+		d.Set("system_channel_flag_names", getSystemChannelFlagNames(*extras.SystemChannelFlags))
+		d.Set("suppress_boost_notifications", *extras.SystemChannelFlags&systemChannelFlags["suppress_premium_subscriptions"] != 0)
+	}
+
 	return diags
 }
 
@@ -107,6 +222,13 @@ func resourceSystemChannelUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
+	if diags := updateSystemChannelFlags(ctx, m, serverId, d); diags.HasError() {
+		return diags
+	}
+	if d.HasChanges("system_channel_id", "system_channel_flags", "system_channel_flag_names") {
+		diags = append(diags, checkSystemJoinMessages(ctx, m, serverId, d)...)
+	}
+
 	return diags
 }
 
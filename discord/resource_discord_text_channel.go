@@ -10,19 +10,31 @@ func resourceDiscordTextChannel() *schema.Resource {
 		ReadContext:   resourceChannelRead,
 		UpdateContext: resourceChannelUpdate,
 		DeleteContext: resourceChannelDelete,
+		CustomizeDiff: customizeChannelDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceChannelImport("text"),
 		},
 		Schema: getChannelSchema("text", map[string]*schema.Schema{
 			"topic": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTopicLength(1024),
 			},
 			"nsfw": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"rate_limit_per_user": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateRateLimitPerUser,
+			},
+			"default_thread_rate_limit_per_user": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateRateLimitPerUser,
+			},
 		}),
 	}
 }
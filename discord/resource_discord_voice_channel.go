@@ -10,8 +10,9 @@ func resourceDiscordVoiceChannel() *schema.Resource {
 		ReadContext:   resourceChannelRead,
 		UpdateContext: resourceChannelUpdate,
 		DeleteContext: resourceChannelDelete,
+		CustomizeDiff: customizeChannelDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceChannelImport("voice"),
 		},
 		Schema: getChannelSchema("voice", map[string]*schema.Schema{
 			"bitrate": {
@@ -23,6 +24,19 @@ func resourceDiscordVoiceChannel() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"nsfw": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"rtc_region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 		}),
 	}
 }
@@ -2,6 +2,12 @@ package discord
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/andersfylling/disgord"
 	"github.com/bwmarrin/discordgo"
@@ -19,11 +25,23 @@ func getTextChannelType(channelType disgord.ChannelType) (string, bool) {
 		return "news", true
 	case 6:
 		return "store", true
+	case 15:
+		return "forum", true
 	}
 
 	return "text", false
 }
 
+// isThreadChannel tells whether the channel is a thread, which only exists below a text, news or forum channel.
+func isThreadChannel(channelType disgord.ChannelType) bool {
+	switch channelType {
+	case disgord.ChannelTypeGuildNewsThread, disgord.ChannelTypeGuildPublicThread, disgord.ChannelTypeGuildPrivateThread:
+		return true
+	}
+
+	return false
+}
+
 func getDiscordChannelType(name string) (disgord.ChannelType, bool) {
 	switch name {
 	case "text":
@@ -36,11 +54,72 @@ func getDiscordChannelType(name string) (disgord.ChannelType, bool) {
 		return 5, true
 	case "store":
 		return 6, true
+	case "forum":
+		return 15, true
+	}
+
+	return 0, false
+}
+
+func getForumSortOrderName(sortOrder int) (string, bool) {
+	switch sortOrder {
+	case 0:
+		return "latest_activity", true
+	case 1:
+		return "creation_date", true
+	}
+
+	return "latest_activity", false
+}
+
+func getDiscordForumSortOrder(name string) (int, bool) {
+	switch name {
+	case "latest_activity":
+		return 0, true
+	case "creation_date":
+		return 1, true
+	}
+
+	return 0, false
+}
+
+func getForumLayoutName(layout int) (string, bool) {
+	switch layout {
+	case 0:
+		return "not_set", true
+	case 1:
+		return "list_view", true
+	case 2:
+		return "gallery_view", true
+	}
+
+	return "not_set", false
+}
+
+func getDiscordForumLayout(name string) (int, bool) {
+	switch name {
+	case "not_set":
+		return 0, true
+	case "list_view":
+		return 1, true
+	case "gallery_view":
+		return 2, true
 	}
 
 	return 0, false
 }
 
+// normalizeChannelName normalizes a name the way Discord does for text, news and forum channels, which are lowercased
+// and have each run of whitespace replaced by a dash.
+func normalizeChannelName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
+
+// hasNormalizedChannelName reports whether Discord normalizes the names of the channel type.
+func hasNormalizedChannelName(channelType string) bool {
+	return channelType == "text" || channelType == "news" || channelType == "forum"
+}
+
 type Channel struct {
 	ServerId  string
 	ChannelId string
@@ -57,6 +136,143 @@ func findChannelById(array []*disgord.Channel, id disgord.Snowflake) *disgord.Ch
 	return nil
 }
 
+// isVoiceSortedChannel reports whether a channel is listed with the voice channels, which Discord puts below the other
+// channels of a category.
+func isVoiceSortedChannel(channelType disgord.ChannelType) bool {
+	return channelType == 2 || channelType == 13
+}
+
+// getCategorySiblings returns the channels listed together with a channel in its category, in the order Discord shows them.
+func getCategorySiblings(channels []*disgord.Channel, channel *disgord.Channel) []*disgord.Channel {
+	siblings := make([]*disgord.Channel, 0)
+	for _, c := range channels {
+		if c.ID == channel.ID || c.ParentID != channel.ParentID || isChannelCategory(c) {
+			continue
+		}
+		if isVoiceSortedChannel(c.Type) == isVoiceSortedChannel(channel.Type) {
+			siblings = append(siblings, c)
+		}
+	}
+	sort.SliceStable(siblings, func(i, j int) bool {
+		if siblings[i].Position != siblings[j].Position {
+			return siblings[i].Position < siblings[j].Position
+		}
+		return siblings[i].ID < siblings[j].ID
+	})
+
+	return siblings
+}
+
+// getPositionWithinCategory returns the place of a channel among the channels of its category, starting at 1.
+func getPositionWithinCategory(channels []*disgord.Channel, channel *disgord.Channel) int {
+	place := 1
+	for _, c := range getCategorySiblings(channels, channel) {
+		if c.Position < channel.Position || (c.Position == channel.Position && c.ID < channel.ID) {
+			place++
+		}
+	}
+
+	return place
+}
+
+// getPositionsWithinCategory returns the absolute positions which put a channel at the given place among the channels
+// of its category, and the position of the channel itself. Only the channels which have to move are returned.
+func getPositionsWithinCategory(channels []*disgord.Channel, channel *disgord.Channel, place int) ([]channelPosition, int) {
+	siblings := getCategorySiblings(channels, channel)
+	index := place - 1
+	if index < 0 {
+		index = 0
+	}
+	if index > len(siblings) {
+		index = len(siblings)
+	}
+	if getPositionWithinCategory(channels, channel) == index+1 {
+		return []channelPosition{}, channel.Position
+	}
+
+	ordered := make([]*disgord.Channel, 0, len(siblings)+1)
+	ordered = append(ordered, siblings[:index]...)
+	ordered = append(ordered, channel)
+	ordered = append(ordered, siblings[index:]...)
+
+	base := channel.Position
+	for _, c := range siblings {
+		if c.Position < base {
+			base = c.Position
+		}
+	}
+
+	positions := make([]channelPosition, 0)
+	position := base
+	for i, c := range ordered {
+		if c.ID == channel.ID {
+			position = base + i
+		}
+		if c.Position != base+i {
+			positions = append(positions, channelPosition{ID: c.ID.String(), Position: base + i})
+		}
+	}
+
+	return positions, position
+}
+
+// updatePositionWithinCategory moves a channel to the given place among the channels of its category, resolved against
+// the channels on Discord at the time. It returns the absolute position the channel ends up at.
+func updatePositionWithinCategory(ctx context.Context, m interface{}, serverId disgord.Snowflake, channelId disgord.Snowflake, place int) (int, error) {
+	channels, err := m.(*Context).Client.Guild(serverId).GetChannels()
+	if err != nil {
+		return 0, err
+	}
+	channel := findChannelById(channels, channelId)
+	if channel == nil {
+		return 0, fmt.Errorf("channel %s does not exist in server %s", channelId.String(), serverId.String())
+	}
+
+	positions, position := getPositionsWithinCategory(channels, channel, place)
+	if len(positions) > 0 {
+		if err := discordRequest(ctx, m, http.MethodPatch, fmt.Sprintf("/guilds/%s/channels", serverId.String()), positions, nil); err != nil {
+			return 0, err
+		}
+	}
+
+	return position, nil
+}
+
+// findChannelByName returns the only channel with the name, among the channels of the type if one is given.
+// Several matches are an error naming each of them, as there is no telling which one is meant.
+func findChannelByName(channels []*disgord.Channel, name string, channelType string) (*disgord.Channel, error) {
+	found := make([]*disgord.Channel, 0)
+	for _, channel := range channels {
+		if t, ok := getTextChannelType(channel.Type); !ok || (channelType != "" && t != channelType) {
+			continue
+		}
+		if channel.Name == name {
+			found = append(found, channel)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		if channelType != "" {
+			return nil, fmt.Errorf("no %s channel named %q exists", channelType, name)
+		}
+		return nil, fmt.Errorf("no channel named %q exists", name)
+	case 1:
+		return found[0], nil
+	default:
+		matches := make([]string, 0, len(found))
+		for _, channel := range found {
+			t, _ := getTextChannelType(channel.Type)
+			matches = append(matches, fmt.Sprintf("%s (%s)", channel.ID.String(), t))
+		}
+		if channelType == "" {
+			return nil, fmt.Errorf("%d channels are named %q: %s, set type to tell them apart",
+				len(found), name, strings.Join(matches, ", "))
+		}
+		return nil, fmt.Errorf("%d channels are named %q: %s", len(found), name, strings.Join(matches, ", "))
+	}
+}
+
 func arePermissionsSynced(from *disgord.Channel, to *disgord.Channel) bool {
 	for _, p1 := range from.PermissionOverwrites {
 		cont := false
@@ -108,6 +324,17 @@ func syncChannelPermissions(c *disgord.Client, ctx context.Context, from *disgor
 	return nil
 }
 
+func getChannelPermissionTypeName(value uint) (string, bool) {
+	switch value {
+	case 0:
+		return "role", true
+	case 1:
+		return "user", true
+	default:
+		return "role", false
+	}
+}
+
 func getDiscordChannelPermissionType(value string) (uint, bool) {
 	switch value {
 	case "role":
@@ -118,3 +345,77 @@ func getDiscordChannelPermissionType(value string) (uint, bool) {
 		return 0, false
 	}
 }
+
+// mergePermissionOverwrite sets the managed bits of a partial overwrite and keeps the ones managed elsewhere.
+// Bits which were managed before but no longer are cleared, and a bit can't be allowed and denied at once.
+func mergePermissionOverwrite(currentAllow, currentDeny, oldAllow, oldDeny, allow, deny uint64) (uint64, uint64) {
+	return (currentAllow &^ oldAllow &^ deny) | allow, (currentDeny &^ oldDeny &^ allow) | deny
+}
+
+// channelExtras holds the channel attributes which disgord doesn't model yet.
+// A null RTC region lets Discord pick the voice region automatically.
+type channelExtras struct {
+	Status                        *string          `json:"status,omitempty"`
+	RateLimitPerUser              *int             `json:"rate_limit_per_user,omitempty"`
+	DefaultThreadRateLimitPerUser *int             `json:"default_thread_rate_limit_per_user,omitempty"`
+	DefaultSortOrder              *int             `json:"default_sort_order,omitempty"`
+	DefaultForumLayout            *int             `json:"default_forum_layout,omitempty"`
+	RTCRegion                     *nullableString  `json:"rtc_region,omitempty"`
+	ParentID                      *nullableString  `json:"parent_id,omitempty"`
+	LockPermissions               *bool            `json:"lock_permissions,omitempty"`
+	LastMessageID                 *string          `json:"last_message_id,omitempty"`
+	DefaultReactionEmoji          *defaultReaction `json:"default_reaction_emoji,omitempty"`
+	Flags                         *int             `json:"flags,omitempty"`
+	AvailableTags                 *[]forumTag      `json:"available_tags,omitempty"`
+}
+
+// forumTag is a tag of a forum channel, only counted to check that require_tag can be fulfilled.
+type forumTag struct {
+	ID string `json:"id"`
+}
+
+// channelFlagRequireTag makes posts in a forum need a tag.
+// See: https://discord.com/developers/docs/resources/channel#channel-object-channel-flags
+const channelFlagRequireTag = 1 << 4
+
+// defaultReaction is the emoji added to new forum posts, either a unicode emoji or the ID of a custom one.
+// It is sent as null without an emoji, which removes the default reaction.
+type defaultReaction struct {
+	EmojiID   nullableString `json:"emoji_id"`
+	EmojiName nullableString `json:"emoji_name"`
+}
+
+func (r defaultReaction) MarshalJSON() ([]byte, error) {
+	if r.EmojiID == "" && r.EmojiName == "" {
+		return []byte("null"), nil
+	}
+
+	type reaction defaultReaction
+	return json.Marshal(reaction(r))
+}
+
+func getChannelExtras(ctx context.Context, m interface{}, channelId disgord.Snowflake) (*channelExtras, error) {
+	var extras channelExtras
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/channels/%s", channelId.String()), nil, &extras); err != nil {
+		return nil, err
+	}
+
+	return &extras, nil
+}
+
+func updateChannelExtras(ctx context.Context, m interface{}, channelId disgord.Snowflake, extras *channelExtras) error {
+	return discordRequest(ctx, m, http.MethodPatch, fmt.Sprintf("/channels/%s", channelId.String()), extras, nil)
+}
+
+// Voice channel status is a newer endpoint which disgord doesn't wrap, and which may not be rolled out everywhere.
+func setVoiceChannelStatus(ctx context.Context, m interface{}, channelId disgord.Snowflake, status string) error {
+	path := fmt.Sprintf("/channels/%s/voice-status", channelId.String())
+	err := discordRequest(ctx, m, http.MethodPut, path, &channelExtras{Status: &status}, nil)
+
+	var apiErr *discordAPIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("voice channel status is not available for channel %s: %s", channelId.String(), apiErr.Error())
+	}
+
+	return err
+}
@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/andersfylling/disgord"
@@ -18,6 +19,7 @@ func TestGetTextChannelType(t *testing.T) {
 		{id: 4, chType: "category", isHit: true},
 		{id: 5, chType: "news", isHit: true},
 		{id: 6, chType: "store", isHit: true},
+		{id: 15, chType: "forum", isHit: true},
 		// failure values
 		{id: 10, chType: "text", isHit: false},
 		{id: 100, chType: "text", isHit: false},
@@ -47,6 +49,7 @@ func TestGetDiscordChannelType(t *testing.T) {
 		{chType: 4, name: "category", isHit: true},
 		{chType: 5, name: "news", isHit: true},
 		{chType: 6, name: "store", isHit: true},
+		{chType: 15, name: "forum", isHit: true},
 		// failure values
 		{chType: 0, name: "lorem", isHit: false},
 		{chType: 0, name: "pesudo", isHit: false},
@@ -62,3 +65,146 @@ func TestGetDiscordChannelType(t *testing.T) {
 		}
 	}
 }
+
+func TestForumSortOrder(t *testing.T) {
+	params := []struct {
+		sortOrder int
+		name      string
+		isHit     bool
+	}{
+		// success values
+		{sortOrder: 0, name: "latest_activity", isHit: true},
+		{sortOrder: 1, name: "creation_date", isHit: true},
+		// failure values
+		{sortOrder: 0, name: "lorem", isHit: false},
+	}
+
+	for _, p := range params {
+		resSortOrder, resIsHit := getDiscordForumSortOrder(p.name)
+		if p.sortOrder != resSortOrder {
+			t.Errorf("name: %v - sortOrder Error: ex: %v, ac: %v", p.name, p.sortOrder, resSortOrder)
+		}
+		if p.isHit != resIsHit {
+			t.Errorf("name: %v - isHit Error: ex: %v, ac: %v", p.name, p.isHit, resIsHit)
+		}
+		if !p.isHit {
+			continue
+		}
+		if resName, _ := getForumSortOrderName(p.sortOrder); p.name != resName {
+			t.Errorf("sortOrder: %v - name Error: ex: %v, ac: %v", p.sortOrder, p.name, resName)
+		}
+	}
+}
+
+func TestForumLayout(t *testing.T) {
+	params := []struct {
+		layout int
+		name   string
+		isHit  bool
+	}{
+		// success values
+		{layout: 0, name: "not_set", isHit: true},
+		{layout: 1, name: "list_view", isHit: true},
+		{layout: 2, name: "gallery_view", isHit: true},
+		// failure values
+		{layout: 0, name: "lorem", isHit: false},
+	}
+
+	for _, p := range params {
+		resLayout, resIsHit := getDiscordForumLayout(p.name)
+		if p.layout != resLayout {
+			t.Errorf("name: %v - layout Error: ex: %v, ac: %v", p.name, p.layout, resLayout)
+		}
+		if p.isHit != resIsHit {
+			t.Errorf("name: %v - isHit Error: ex: %v, ac: %v", p.name, p.isHit, resIsHit)
+		}
+		if !p.isHit {
+			continue
+		}
+		if resName, _ := getForumLayoutName(p.layout); p.name != resName {
+			t.Errorf("layout: %v - name Error: ex: %v, ac: %v", p.layout, p.name, resName)
+		}
+	}
+}
+
+func TestMergePermissionOverwrite(t *testing.T) {
+	params := []struct {
+		current  [2]uint64
+		old      [2]uint64
+		new      [2]uint64
+		expected [2]uint64
+	}{
+		// Bits managed elsewhere are kept.
+		{current: [2]uint64{0b0001, 0b1000}, old: [2]uint64{0, 0}, new: [2]uint64{0b0010, 0b0100}, expected: [2]uint64{0b0011, 0b1100}},
+		// Allowing a denied bit moves it over.
+		{current: [2]uint64{0b0001, 0b0010}, old: [2]uint64{0, 0}, new: [2]uint64{0b0010, 0}, expected: [2]uint64{0b0011, 0}},
+		// Bits no longer managed are cleared.
+		{current: [2]uint64{0b0011, 0b0100}, old: [2]uint64{0b0010, 0b0100}, new: [2]uint64{0, 0}, expected: [2]uint64{0b0001, 0}},
+	}
+
+	for _, p := range params {
+		allow, deny := mergePermissionOverwrite(p.current[0], p.current[1], p.old[0], p.old[1], p.new[0], p.new[1])
+		if allow != p.expected[0] || deny != p.expected[1] {
+			t.Errorf("current: %v, new: %v - masks Error: ex: %v, ac: %v", p.current, p.new, p.expected, [2]uint64{allow, deny})
+		}
+	}
+}
+
+func TestPositionsWithinCategory(t *testing.T) {
+	channels := []*disgord.Channel{
+		{ID: 10, Type: 4, Position: 0},
+		{ID: 1, Type: 0, ParentID: 10, Position: 3},
+		{ID: 2, Type: 0, ParentID: 10, Position: 5},
+		{ID: 3, Type: 0, ParentID: 10, Position: 7},
+		{ID: 4, Type: 2, ParentID: 10, Position: 1},
+		{ID: 5, Type: 0, ParentID: 11, Position: 4},
+	}
+
+	places := map[disgord.Snowflake]int{1: 1, 2: 2, 3: 3, 4: 1, 5: 1}
+	for id, expected := range places {
+		if place := getPositionWithinCategory(channels, findChannelById(channels, id)); place != expected {
+			t.Errorf("channel: %v - place Error: ex: %v, ac: %v", id, expected, place)
+		}
+	}
+
+	params := []struct {
+		channelId disgord.Snowflake
+		place     int
+		positions []channelPosition
+		position  int
+	}{
+		{channelId: 3, place: 1, positions: []channelPosition{{ID: "3", Position: 3}, {ID: "1", Position: 4}}, position: 3},
+		{channelId: 1, place: 9, positions: []channelPosition{{ID: "2", Position: 3}, {ID: "3", Position: 4}, {ID: "1", Position: 5}}, position: 5},
+		{channelId: 2, place: 2, positions: []channelPosition{}, position: 5},
+		{channelId: 4, place: 2, positions: []channelPosition{}, position: 1},
+	}
+
+	for _, p := range params {
+		positions, position := getPositionsWithinCategory(channels, findChannelById(channels, p.channelId), p.place)
+		if !reflect.DeepEqual(positions, p.positions) {
+			t.Errorf("channel: %v, place: %v - positions Error: ex: %v, ac: %v", p.channelId, p.place, p.positions, positions)
+		}
+		if position != p.position {
+			t.Errorf("channel: %v, place: %v - position Error: ex: %v, ac: %v", p.channelId, p.place, p.position, position)
+		}
+	}
+}
+
+func TestNormalizeChannelName(t *testing.T) {
+	params := []struct {
+		name     string
+		expected string
+	}{
+		{name: "general", expected: "general"},
+		{name: "General", expected: "general"},
+		{name: "My Channel", expected: "my-channel"},
+		{name: " My   Big\tChannel ", expected: "my-big-channel"},
+		{name: "Ünïcode Chat", expected: "ünïcode-chat"},
+	}
+
+	for _, p := range params {
+		if ac := normalizeChannelName(p.name); ac != p.expected {
+			t.Errorf("name: %q - normalized Error: ex: %v, ac: %v", p.name, p.expected, ac)
+		}
+	}
+}
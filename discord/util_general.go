@@ -3,6 +3,7 @@ package discord
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/andersfylling/disgord"
 )
@@ -17,6 +18,35 @@ func parseTwoIds(id string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// parseNameImportId splits an import ID of the form server_id/name, used to import resources by their name.
+func parseNameImportId(id string) (string, string, bool) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// findIdByName returns the only ID with the given name, an import by name has to be unambiguous.
+func findIdByName(kind string, names map[disgord.Snowflake]string, name string) (disgord.Snowflake, error) {
+	var found []disgord.Snowflake
+	for id, n := range names {
+		if n == name {
+			found = append(found, id)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return 0, fmt.Errorf("no %s named %q exists", kind, name)
+	case 1:
+		return found[0], nil
+	default:
+		return 0, fmt.Errorf("%d %ss are named %q, import it by ID instead", len(found), kind, name)
+	}
+}
+
 // Helper function for generating a two part ID
 func generateTwoPartId(one string, two string) string {
 	return fmt.Sprintf("%s:%s", one, two)
@@ -56,3 +86,8 @@ func getBothIds(v interface{}) (disgord.Snowflake, disgord.Snowflake, error) {
 
 	return disgord.ParseSnowflakeString(firstId), disgord.ParseSnowflakeString(secondId), nil
 }
+
+// getSnowflakeTime returns the creation time embedded in a Discord ID in RFC 3339 format.
+func getSnowflakeTime(id disgord.Snowflake) string {
+	return id.Date().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,34 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/andersfylling/disgord"
+)
+
+func TestSnowflakeTime(t *testing.T) {
+	// See: https://discord.com/developers/docs/reference#snowflakes
+	if ac := getSnowflakeTime(175928847299117063); ac != "2016-04-30T11:18:25Z" {
+		t.Errorf("created_at Error: ex: %v, ac: %v", "2016-04-30T11:18:25Z", ac)
+	}
+}
+
+func TestFindIdByName(t *testing.T) {
+	if serverId, name, ok := parseNameImportId("1/general/chat"); !ok || serverId != "1" || name != "general/chat" {
+		t.Errorf("parse Error: ex: %v, ac: %v", "1 general/chat", []interface{}{serverId, name, ok})
+	}
+	if _, _, ok := parseNameImportId("1:2"); ok {
+		t.Errorf("composite id Error: ex: %v, ac: %v", false, ok)
+	}
+
+	names := map[disgord.Snowflake]string{1: "mods", 2: "members", 3: "members"}
+	if id, err := findIdByName("role", names, "mods"); err != nil || id != 1 {
+		t.Errorf("unique Error: ex: %v, ac: %v", 1, id)
+	}
+	if _, err := findIdByName("role", names, "members"); err == nil {
+		t.Errorf("ambiguous Error: ex: %v, ac: %v", "an error", err)
+	}
+	if _, err := findIdByName("role", names, "admins"); err == nil {
+		t.Errorf("missing Error: ex: %v, ac: %v", "an error", err)
+	}
+}
@@ -0,0 +1,155 @@
+package discord
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// supportedImageTypes are the formats Discord accepts for server images.
+var supportedImageTypes = []string{"image/png", "image/jpeg", "image/gif"}
+
+// getImageDataURI encodes an image as a data URI, with the MIME type detected from its contents.
+func getImageDataURI(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !contains(supportedImageTypes, contentType) {
+		return "", fmt.Errorf("unsupported image format %s, expected one of %v", contentType, supportedImageTypes)
+	}
+
+	return fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data)), nil
+}
+
+func getImageFileDataURI(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return getImageDataURI(data)
+}
+
+// getRemoteImageDataURI downloads an image as a data URI. The MIME type reported by the host isn't always right,
+// so it is detected again from the downloaded bytes.
+func getRemoteImageDataURI(link string) (string, error) {
+	res, err := http.Get(link)
+	if err != nil {
+		return "", fmt.Errorf("failed to download image from %s: %s", link, err.Error())
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download image from %s: %s", link, res.Status)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to download image from %s: %s", link, err.Error())
+	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("failed to download image from %s: the response is empty", link)
+	}
+
+	return getImageDataURI(data)
+}
+
+// checkAnimatedIcon rejects GIF icons on servers which can't have animated icons, which Discord fails with a vague error.
+func checkAnimatedIcon(icon string, features []string) error {
+	if strings.HasPrefix(icon, "data:image/gif") && !contains(features, serverFeatureAnimatedIcon) {
+		return fmt.Errorf("animated GIF icons require the %s feature, which the server doesn't have. Use a PNG or JPEG image instead", serverFeatureAnimatedIcon)
+	}
+
+	return nil
+}
+
+// getImageDataURIValue accepts a data URI as is. Plain base64, as returned by filebase64(), is turned into a data URI
+// with the MIME type detected from the decoded bytes, since Discord rejects images without the prefix.
+func getImageDataURIValue(value string) (string, error) {
+	if strings.HasPrefix(value, "data:") {
+		return value, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil || len(data) == 0 {
+		return "", fmt.Errorf("expected a data URI such as data:image/png;base64,... or the base64 encoded image returned by filebase64()")
+	}
+
+	return getImageDataURI(data)
+}
+
+func validateImageDataURI(val interface{}, key string) (warns []string, errors []error) {
+	if _, err := getImageDataURIValue(val.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s is invalid: %s", key, err.Error()))
+	}
+
+	return
+}
+
+func validateImageFile(val interface{}, key string) (warns []string, errors []error) {
+	v := val.(string)
+	if _, err := getImageFileDataURI(v); err != nil {
+		errors = append(errors, fmt.Errorf("%s must be a PNG, JPEG or GIF image: %s", key, err.Error()))
+	}
+
+	return
+}
+
+// serverImagePaths are the CDN folders of the server images which can be copied from another server.
+var serverImagePaths = map[string]string{
+	"icon":   "icons",
+	"splash": "splashes",
+}
+
+// getServerImageURL returns the CDN URL of a server image. Animated images have a hash starting with a_.
+func getServerImageURL(name string, serverId disgord.Snowflake, hash string) string {
+	extension := "png"
+	if strings.HasPrefix(hash, "a_") {
+		extension = "gif"
+	}
+
+	return fmt.Sprintf("https://cdn.discordapp.com/%s/%s/%s.%s", serverImagePaths[name], serverId.String(), hash, extension)
+}
+
+// getServerImageDataURI downloads the current icon or splash of another server.
+func getServerImageDataURI(client *disgord.Client, serverId disgord.Snowflake, name string) (string, error) {
+	source, err := client.Guild(serverId).Get()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch server %s: %s", serverId.String(), err.Error())
+	}
+
+	hash := map[string]string{"icon": source.Icon, "splash": source.Splash}[name]
+	if hash == "" {
+		return "", fmt.Errorf("server %s has no %s to copy", serverId.String(), name)
+	}
+
+	return getRemoteImageDataURI(getServerImageURL(name, serverId, hash))
+}
+
+// getConfiguredImage returns the data URI of an image which can be given as <name>_url, <name>_data_uri, <name>_file
+// or copied from another server with <name>_from_server_id.
+func getConfiguredImage(client *disgord.Client, d *schema.ResourceData, name string) (string, error) {
+	if v, ok := d.GetOk(name + "_url"); ok {
+		return getRemoteImageDataURI(v.(string))
+	}
+	if v, ok := d.GetOk(name + "_data_uri"); ok {
+		return getImageDataURIValue(v.(string))
+	}
+	if v, ok := d.GetOk(name + "_file"); ok {
+		return getImageFileDataURI(v.(string))
+	}
+	if v, ok := d.GetOk(name + "_from_server_id"); ok {
+		return getServerImageDataURI(client, getId(v.(string)), name)
+	}
+
+	return "", nil
+}
+
+// hasImageChange reports whether any of the arguments of an image changed.
+func hasImageChange(d *schema.ResourceData, name string) bool {
+	return d.HasChanges(name+"_url", name+"_data_uri", name+"_file", name+"_from_server_id")
+}
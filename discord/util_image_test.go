@@ -0,0 +1,129 @@
+package discord
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+func TestImageFileDataURI(t *testing.T) {
+	dir := t.TempDir()
+	png := filepath.Join(dir, "icon.png")
+	if err := os.WriteFile(png, testPNG, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	text := filepath.Join(dir, "icon.txt")
+	if err := os.WriteFile(text, []byte("not an image"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	uri, err := getImageFileDataURI(png)
+	if err != nil {
+		t.Fatalf("png Error: ex: %v, ac: %v", nil, err)
+	}
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Errorf("png Error: ex: %v, ac: %v", "data:image/png;base64,...", uri)
+	}
+
+	if _, errs := validateImageFile(text, "icon_file"); len(errs) == 0 {
+		t.Errorf("text Error: ex: %v, ac: %v", "an unsupported format error", errs)
+	}
+	if _, errs := validateImageFile(filepath.Join(dir, "missing.png"), "icon_file"); len(errs) == 0 {
+		t.Errorf("missing Error: ex: %v, ac: %v", "a missing file error", errs)
+	}
+}
+
+func TestImageDataURIValue(t *testing.T) {
+	params := []struct {
+		value    string
+		expected string
+		err      bool
+	}{
+		{value: "data:image/png;base64,AAAA", expected: "data:image/png;base64,AAAA"},
+		{value: base64.StdEncoding.EncodeToString(testPNG), expected: "data:image/png;base64," + base64.StdEncoding.EncodeToString(testPNG)},
+		{value: base64.StdEncoding.EncodeToString([]byte("not an image")), err: true},
+		{value: "icon.png", err: true},
+	}
+
+	for _, p := range params {
+		ac, err := getImageDataURIValue(p.value)
+		if (err != nil) != p.err {
+			t.Errorf("value: %v - error Error: ex: %v, ac: %v", p.value, p.err, err)
+			continue
+		}
+		if ac != p.expected {
+			t.Errorf("value: %v - data URI Error: ex: %v, ac: %v", p.value, p.expected, ac)
+		}
+	}
+}
+
+func TestRemoteImageDataURI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/icon" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(testPNG)
+	}))
+	defer server.Close()
+
+	uri, err := getRemoteImageDataURI(server.URL + "/icon")
+	if err != nil {
+		t.Fatalf("download Error: ex: %v, ac: %v", nil, err)
+	}
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Errorf("content type Error: ex: %v, ac: %v", "data:image/png;base64,...", uri)
+	}
+
+	if _, err := getRemoteImageDataURI(server.URL + "/missing"); err == nil {
+		t.Errorf("status Error: ex: %v, ac: %v", "an error", err)
+	}
+	if _, err := getRemoteImageDataURI("http://127.0.0.1:0/icon"); err == nil {
+		t.Errorf("network Error: ex: %v, ac: %v", "an error", err)
+	}
+}
+
+func TestCheckAnimatedIcon(t *testing.T) {
+	gif := "data:image/gif;base64,R0lGODlh"
+	if err := checkAnimatedIcon(gif, []string{"COMMUNITY"}); err == nil {
+		t.Errorf("without feature Error: ex: %v, ac: %v", "an error", err)
+	}
+	if err := checkAnimatedIcon(gif, []string{serverFeatureAnimatedIcon}); err != nil {
+		t.Errorf("with feature Error: ex: %v, ac: %v", nil, err)
+	}
+	if err := checkAnimatedIcon("data:image/png;base64,iVBORw0K", nil); err != nil {
+		t.Errorf("png Error: ex: %v, ac: %v", nil, err)
+	}
+}
+
+func TestServerImageDataURI(t *testing.T) {
+	params := []struct {
+		name     string
+		hash     string
+		expected string
+	}{
+		{name: "icon", hash: "abc", expected: "https://cdn.discordapp.com/icons/9/abc.png"},
+		{name: "icon", hash: "a_abc", expected: "https://cdn.discordapp.com/icons/9/a_abc.gif"},
+		{name: "splash", hash: "def", expected: "https://cdn.discordapp.com/splashes/9/def.png"},
+	}
+
+	for _, p := range params {
+		if ac := getServerImageURL(p.name, getId("9"), p.hash); ac != p.expected {
+			t.Errorf("name: %v, hash: %v - url Error: ex: %v, ac: %v", p.name, p.hash, p.expected, ac)
+		}
+	}
+
+	c, _ := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/9": {{status: http.StatusOK, body: `{"id": "9", "icon": "abc"}`}},
+	})
+	if _, err := getServerImageDataURI(c.Client, getId("9"), "splash"); err == nil || !strings.Contains(err.Error(), "has no splash") {
+		t.Errorf("missing splash Error: ex: %v, ac: %v", "server 9 has no splash to copy", err)
+	}
+}
@@ -1,6 +1,14 @@
 package discord
 
-import "github.com/andersfylling/disgord"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/andersfylling/disgord"
+)
 
 func hasRole(member *disgord.Member, roleId disgord.Snowflake) bool {
 	for _, r := range member.Roles {
@@ -11,3 +19,81 @@ func hasRole(member *disgord.Member, roleId disgord.Snowflake) bool {
 
 	return false
 }
+
+// checkServerMember fails when the user isn't a member of the server, which Discord requires e.g. of a new owner.
+func checkServerMember(client *disgord.Client, serverId disgord.Snowflake, userId disgord.Snowflake) error {
+	if _, err := client.Guild(serverId).Member(userId).Get(); err != nil {
+		if isDiscordError(err, discordErrorUnknownMember) {
+			return fmt.Errorf("user %s is not a member of server %s, they have to join it first", userId.String(), serverId.String())
+		}
+		return fmt.Errorf("failed to fetch member %s: %s", userId.String(), err.Error())
+	}
+
+	return nil
+}
+
+// serverMember is a member along with the attributes which disgord doesn't model yet.
+type serverMember struct {
+	disgord.Member
+	Flags int `json:"flags"`
+}
+
+// memberFlagBypassesVerification lets a member talk without meeting the verification level of the server.
+// See: https://discord.com/developers/docs/resources/guild#guild-member-object-guild-member-flags
+const memberFlagBypassesVerification = 1 << 2
+
+// memberEdit is the modify guild member payload, sent apart from disgord which doesn't send the flags.
+type memberEdit struct {
+	Roles *[]disgord.Snowflake `json:"roles,omitempty"`
+	Flags *int                 `json:"flags,omitempty"`
+}
+
+// setMemberFlag turns a flag of the member on or off and keeps the other flags.
+func setMemberFlag(flags int, flag int, on bool) int {
+	if on {
+		return flags | flag
+	}
+
+	return flags &^ flag
+}
+
+// memberPageSize is the largest number of members Discord returns at once.
+var memberPageSize = 1000
+
+func getServerMember(ctx context.Context, m interface{}, serverId disgord.Snowflake, userId disgord.Snowflake) (*serverMember, error) {
+	var member serverMember
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s/members/%s", serverId.String(), userId.String()), nil, &member); err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// getServerMembers fetches every member of the server page by page.
+func getServerMembers(ctx context.Context, m interface{}, serverId disgord.Snowflake) ([]*serverMember, error) {
+	members := make([]*serverMember, 0)
+	after := ""
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(memberPageSize))
+		if after != "" {
+			query.Set("after", after)
+		}
+
+		var page []*serverMember
+		if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s/members?%s", serverId.String(), query.Encode()), nil, &page); err != nil {
+			return nil, err
+		}
+
+		for _, member := range page {
+			if member.User != nil {
+				after = member.User.ID.String()
+			}
+		}
+		members = append(members, page...)
+
+		if len(page) < memberPageSize {
+			return members, nil
+		}
+	}
+}
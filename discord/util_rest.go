@@ -0,0 +1,159 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/andersfylling/disgord"
+)
+
+const discordAPIBaseURL = "https://discord.com/api/v10"
+
+// See: https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+const (
+	discordErrorUnknownChannel            = 10003
+	discordErrorUnknownGuild              = 10004
+	discordErrorUnknownIntegration        = 10005
+	discordErrorUnknownMember             = 10007
+	discordErrorUnknownRole               = 10011
+	discordErrorUnknownEmoji              = 10014
+	discordErrorUnknownGuildTemplate      = 10057
+	discordErrorUnknownVoiceState         = 10065
+	discordErrorUnknownCommandPermissions = 10066
+	discordErrorMaxServers                = 30001
+	discordErrorMaxPins                   = 30003
+	discordErrorMissingAccess             = 50001
+	discordErrorNotConnectedToVoice       = 40032
+	discordErrorWidgetDisabled            = 50004
+	discordErrorMFARequired               = 60003
+)
+
+// discordAPIError is the error body returned by the Discord REST API.
+type discordAPIError struct {
+	StatusCode int    `json:"-"`
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *discordAPIError) Error() string {
+	return fmt.Sprintf("%d %s (code %d)", e.StatusCode, e.Message, e.Code)
+}
+
+// nullableString is sent as null when empty, which is how Discord expects an attribute to be cleared.
+type nullableString string
+
+func (s nullableString) MarshalJSON() ([]byte, error) {
+	if s == "" {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(string(s))
+}
+
+// isDiscordError reports whether err is an error returned by the Discord API with one of the given JSON error codes.
+func isDiscordError(err error, codes ...int) bool {
+	var restErr *disgord.ErrRest
+	if errors.As(err, &restErr) {
+		return contains(codes, restErr.Code)
+	}
+
+	var apiErr *discordAPIError
+	if errors.As(err, &apiErr) {
+		return contains(codes, apiErr.Code)
+	}
+
+	return false
+}
+
+// discordErrorStatus returns the HTTP status code of an error returned by the Discord API, or 0 for other errors.
+func discordErrorStatus(err error) int {
+	var restErr *disgord.ErrRest
+	if errors.As(err, &restErr) {
+		return restErr.HTTPCode
+	}
+
+	var apiErr *discordAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
+
+// discordRequest calls an endpoint of the Discord REST API which disgord doesn't cover.
+// The request goes through the same rate limited HTTP client as disgord.
+func discordRequest(ctx context.Context, m interface{}, method string, path string, body interface{}, out interface{}) error {
+	return discordRequestWithHeaders(ctx, m, nil, method, path, body, out)
+}
+
+// discordRequestWithAuthorization is discordRequest for the endpoints which don't accept the bot token,
+// e.g. those which need an OAuth2 bearer token.
+func discordRequestWithAuthorization(ctx context.Context, m interface{}, authorization string, method string, path string, body interface{}, out interface{}) error {
+	return discordRequestWithHeaders(ctx, m, http.Header{"Authorization": []string{authorization}}, method, path, body, out)
+}
+
+// auditLogReason is the header for the reason shown in the audit log, which some endpoints also store, e.g. bans.
+func auditLogReason(reason string) http.Header {
+	header := http.Header{}
+	if reason != "" {
+		header.Set("X-Audit-Log-Reason", url.PathEscape(reason))
+	}
+
+	return header
+}
+
+// discordRequestWithHeaders is discordRequest with additional headers, which override the default ones.
+func discordRequestWithHeaders(ctx context.Context, m interface{}, header http.Header, method string, path string, body interface{}, out interface{}) error {
+	c := m.(*Context)
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, discordAPIBaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+c.Config.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= 400 {
+		apiErr := &discordAPIError{StatusCode: res.StatusCode, Message: http.StatusText(res.StatusCode)}
+		json.Unmarshal(data, apiErr)
+
+		return apiErr
+	}
+
+	if out != nil && len(data) > 0 {
+		return json.Unmarshal(data, out)
+	}
+
+	return nil
+}
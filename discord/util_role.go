@@ -2,11 +2,94 @@ package discord
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
 
 	"github.com/andersfylling/disgord"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// validateRolePermissions rejects negative permissions, which come from trying to deny permissions on a role.
+// Roles can only allow permissions, denying them is done with the permission overwrites of a channel.
+func validateRolePermissions(val interface{}, key string) (warns []string, errors []error) {
+	v := val.(int)
+	if v < 0 {
+		errors = append(errors, fmt.Errorf("%s of a role can only allow permissions, got: %d. "+
+			"To deny permissions, leave them out here and add a channel permission overwrite for the role instead, "+
+			"e.g. discord_channel_permission with type = \"role\" and the server ID as overwrite_id for @everyone", key, v))
+	}
+
+	return
+}
+
+func validatePermissionName(val interface{}, key string) (warns []string, errors []error) {
+	v := val.(string)
+	if _, ok := permissions[v]; !ok {
+		errors = append(errors, fmt.Errorf("%s is not a permission, got: %s", key, v))
+	}
+
+	return
+}
+
+func hasIncrementalPermissions(d *schema.ResourceData) bool {
+	return d.Get("add_permissions").(*schema.Set).Len() > 0 || d.Get("remove_permissions").(*schema.Set).Len() > 0
+}
+
+func getPermissionBits(names *schema.Set) uint64 {
+	var bits uint64
+	for _, name := range names.List() {
+		bits |= uint64(permissions[name.(string)])
+	}
+
+	return bits
+}
+
+// getPermissionNames returns the sorted names of the permissions set in bits.
+func getPermissionNames(bits uint64) []string {
+	names := make([]string, 0)
+	for name, bit := range permissions {
+		if bits&uint64(bit) != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// mergeRolePermissions applies add_permissions and remove_permissions to the current permissions of a role.
+// A permission in both lists is removed.
+func mergeRolePermissions(current uint64, d *schema.ResourceData) uint64 {
+	add := getPermissionBits(d.Get("add_permissions").(*schema.Set))
+	remove := getPermissionBits(d.Get("remove_permissions").(*schema.Set))
+
+	return (current | add) &^ remove
+}
+
+// countRoleMembers counts the members with a role, which pages through every member of the server.
+// Everyone has the @everyone role, whose ID is the one of the server.
+func countRoleMembers(client *disgord.Client, serverId disgord.Snowflake, roleId disgord.Snowflake) (int, error) {
+	members, err := client.Guild(serverId).GetMembers(&disgord.GetMembers{Limit: 0})
+	if err != nil {
+		return 0, err
+	}
+	if roleId == serverId {
+		return len(members), nil
+	}
+
+	count := 0
+	for _, member := range members {
+		if hasRole(member, roleId) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
 type Role struct {
 	ServerId disgord.Snowflake
 	RoleId   disgord.Snowflake
@@ -83,6 +166,183 @@ func reorderRoles(ctx context.Context, m interface{}, serverId disgord.Snowflake
 	return true, nil
 }
 
+// getRelativeRolePositions places the role directly above or below the target role and returns the positions of all roles
+// along with the resolved position of the role. Roles are ordered bottom up, @everyone always stays at the bottom.
+func getRelativeRolePositions(roles []*disgord.Role, roleId disgord.Snowflake, targetId disgord.Snowflake, above bool) ([]disgord.UpdateGuildRolePositions, int, error) {
+	if roleId == targetId {
+		return nil, 0, fmt.Errorf("role %s can't be positioned relative to itself", roleId.String())
+	}
+
+	ordered := make([]*disgord.Role, 0, len(roles))
+	var role, target *disgord.Role
+	for _, r := range roles {
+		switch r.ID {
+		case roleId:
+			role = r
+		case targetId:
+			target = r
+			ordered = append(ordered, r)
+		default:
+			ordered = append(ordered, r)
+		}
+	}
+	if role == nil {
+		return nil, 0, fmt.Errorf("role %s doesn't exist", roleId.String())
+	}
+	if target == nil {
+		return nil, 0, fmt.Errorf("role %s doesn't exist in the server of role %s", targetId.String(), roleId.String())
+	}
+	if !above && target.Position == 0 {
+		return nil, 0, fmt.Errorf("role %s can't be below @everyone", roleId.String())
+	}
+
+	ordered = sortRolesByPosition(ordered)
+
+	index, _ := findRoleIndex(ordered, target)
+	if above {
+		index++
+	}
+	ordered = insertRole(ordered, role, index)
+
+	params := make([]disgord.UpdateGuildRolePositions, 0, len(ordered))
+	for i, r := range ordered {
+		params = append(params, disgord.UpdateGuildRolePositions{ID: r.ID, Position: i})
+	}
+
+	return params, index, nil
+}
+
+// isRolePlacedRelative tells whether the role sits directly above or below the target role.
+func isRolePlacedRelative(roles []*disgord.Role, roleId disgord.Snowflake, targetId disgord.Snowflake, above bool) bool {
+	ordered := sortRolesByPosition(roles)
+	roleIndex, targetIndex := -1, -1
+	for i, r := range ordered {
+		switch r.ID {
+		case roleId:
+			roleIndex = i
+		case targetId:
+			targetIndex = i
+		}
+	}
+	if roleIndex < 0 || targetIndex < 0 {
+		return false
+	}
+	if above {
+		return roleIndex == targetIndex+1
+	}
+
+	return roleIndex == targetIndex-1
+}
+
+// updateRelativeRolePosition moves the role above or below the role configured in above_role_id or below_role_id.
+func updateRelativeRolePosition(client *disgord.Client, serverId disgord.Snowflake, roleId disgord.Snowflake, targetId disgord.Snowflake, above bool) (int, diag.Diagnostics) {
+	roles, err := client.Guild(serverId).GetRoles()
+	if err != nil {
+		return 0, diag.Errorf("Failed to fetch roles: %s", err.Error())
+	}
+
+	params, position, err := getRelativeRolePositions(roles, roleId, targetId, above)
+	if err != nil {
+		return 0, diag.Errorf("Failed to re-order roles: %s", err.Error())
+	}
+
+	if _, err := client.Guild(serverId).UpdateRolePositions(params); err != nil {
+		return 0, diag.Errorf("Failed to re-order roles: %s", err.Error())
+	}
+
+	return position, nil
+}
+
+// getMemberServerPermissions returns the server-wide permissions of a member, given by @everyone and the member's roles.
+// The owner and administrators have every permission.
+func getMemberServerPermissions(roles []*disgord.Role, serverId disgord.Snowflake, member *disgord.Member, isOwner bool) uint64 {
+	var bits uint64
+	for _, r := range roles {
+		if r.ID == serverId || hasRole(member, r.ID) {
+			bits |= uint64(r.Permissions)
+		}
+	}
+
+	if isOwner || bits&uint64(permissions["administrator"]) != 0 {
+		for _, bit := range permissions {
+			bits |= uint64(bit)
+		}
+	}
+
+	return bits
+}
+
+// getBotServerPermissions returns the server-wide permissions of the bot, without the overwrites of any channel.
+func getBotServerPermissions(client *disgord.Client, serverId disgord.Snowflake, botId disgord.Snowflake, isOwner bool) (uint64, error) {
+	member, err := client.Guild(serverId).Member(botId).Get()
+	if err != nil {
+		return 0, err
+	}
+	roles, err := client.Guild(serverId).GetRoles()
+	if err != nil {
+		return 0, err
+	}
+
+	return getMemberServerPermissions(roles, serverId, member, isOwner), nil
+}
+
+// sortRolesByPosition orders roles bottom up, roles sharing a position are ordered by ID like in the Discord client.
+func sortRolesByPosition(roles []*disgord.Role) []*disgord.Role {
+	ordered := make([]*disgord.Role, len(roles))
+	copy(ordered, roles)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Position == ordered[j].Position {
+			return ordered[i].ID < ordered[j].ID
+		}
+
+		return ordered[i].Position < ordered[j].Position
+	})
+
+	return ordered
+}
+
+// getRoleOrderPositions reorders the given roles, listed from the top down, among the positions they already take.
+// The other roles keep their place, so only the listed roles move relative to each other.
+func getRoleOrderPositions(roles []*disgord.Role, serverId disgord.Snowflake, roleIds []disgord.Snowflake) ([]disgord.UpdateGuildRolePositions, error) {
+	ordered := sortRolesByPosition(roles)
+
+	slots := make([]int, 0, len(roleIds))
+	for i, r := range ordered {
+		for _, id := range roleIds {
+			if r.ID == id {
+				slots = append(slots, i)
+				break
+			}
+		}
+	}
+
+	wanted := make([]*disgord.Role, 0, len(roleIds))
+	for i := len(roleIds) - 1; i >= 0; i-- {
+		if roleIds[i] == serverId {
+			return nil, fmt.Errorf("@everyone is always the lowest role and can't be ordered")
+		}
+		role := findRoleById(ordered, roleIds[i])
+		if role == nil {
+			return nil, fmt.Errorf("role %s doesn't exist in server %s", roleIds[i].String(), serverId.String())
+		}
+		wanted = append(wanted, role)
+	}
+	if len(slots) != len(wanted) {
+		return nil, fmt.Errorf("roles can only be listed once")
+	}
+
+	for i, slot := range slots {
+		ordered[slot] = wanted[i]
+	}
+
+	params := make([]disgord.UpdateGuildRolePositions, 0, len(ordered))
+	for i, r := range ordered {
+		params = append(params, disgord.UpdateGuildRolePositions{ID: r.ID, Position: i})
+	}
+
+	return params, nil
+}
+
 func getRole(ctx context.Context, client *disgord.Client, serverId disgord.Snowflake, roleId disgord.Snowflake) (*disgord.Role, error) {
 	if roles, err := client.Guild(serverId).GetRoles(); err != nil {
 		return nil, err
@@ -91,3 +351,68 @@ func getRole(ctx context.Context, client *disgord.Client, serverId disgord.Snowf
 		return role, nil
 	}
 }
+
+// roleTags is decoded as a map, as Discord marks the booster role by a premium_subscriber key with a null value.
+type roleTags map[string]json.RawMessage
+
+// serverRole is a role along with its tags, which tell apart the roles managed by Discord, bots and integrations.
+type serverRole struct {
+	disgord.Role
+	Tags roleTags `json:"tags"`
+}
+
+func roleTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"is_premium_subscriber": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"bot_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"integration_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// getServerRoles fetches the roles of the server along with their tags in a single request.
+func getServerRoles(ctx context.Context, m interface{}, serverId disgord.Snowflake) ([]*serverRole, error) {
+	var roles []*serverRole
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s/roles", serverId.String()), nil, &roles); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func findServerRole(roles []*serverRole, roleId disgord.Snowflake) *serverRole {
+	for _, r := range roles {
+		if r.ID == roleId {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func flattenRoleTags(tags roleTags) []map[string]interface{} {
+	var botId, integrationId string
+	json.Unmarshal(tags["bot_id"], &botId)
+	json.Unmarshal(tags["integration_id"], &integrationId)
+	_, premiumSubscriber := tags["premium_subscriber"]
+
+	return []map[string]interface{}{{
+		"is_premium_subscriber": premiumSubscriber,
+		"bot_id":                botId,
+		"integration_id":        integrationId,
+	}}
+}
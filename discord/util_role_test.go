@@ -0,0 +1,222 @@
+package discord
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestRelativeRolePositions(t *testing.T) {
+	roles := []*disgord.Role{
+		{ID: 3, Position: 2},
+		{ID: 1, Position: 0},
+		{ID: 4, Position: 3},
+		{ID: 2, Position: 1},
+	}
+
+	params := []struct {
+		role     disgord.Snowflake
+		target   disgord.Snowflake
+		above    bool
+		order    []disgord.Snowflake
+		position int
+		err      bool
+	}{
+		{role: 4, target: 2, above: true, order: []disgord.Snowflake{1, 2, 4, 3}, position: 2},
+		{role: 2, target: 4, above: false, order: []disgord.Snowflake{1, 3, 2, 4}, position: 2},
+		{role: 2, target: 4, above: true, order: []disgord.Snowflake{1, 3, 4, 2}, position: 3},
+		{role: 3, target: 1, above: true, order: []disgord.Snowflake{1, 3, 2, 4}, position: 1},
+		{role: 3, target: 1, above: false, err: true},
+		{role: 3, target: 5, above: true, err: true},
+		{role: 3, target: 3, above: true, err: true},
+	}
+
+	for _, p := range params {
+		resParams, resPosition, err := getRelativeRolePositions(roles, p.role, p.target, p.above)
+		if (err != nil) != p.err {
+			t.Errorf("role: %v, target: %v, above: %v - error Error: ex: %v, ac: %v", p.role, p.target, p.above, p.err, err)
+			continue
+		}
+		if p.err {
+			continue
+		}
+
+		order := make([]disgord.Snowflake, 0, len(resParams))
+		for i, r := range resParams {
+			if r.Position != i {
+				t.Errorf("role: %v, target: %v, above: %v - position of %v Error: ex: %v, ac: %v", p.role, p.target, p.above, r.ID, i, r.Position)
+			}
+			order = append(order, r.ID)
+		}
+		if !reflect.DeepEqual(p.order, order) {
+			t.Errorf("role: %v, target: %v, above: %v - order Error: ex: %v, ac: %v", p.role, p.target, p.above, p.order, order)
+		}
+		if resPosition != p.position {
+			t.Errorf("role: %v, target: %v, above: %v - position Error: ex: %v, ac: %v", p.role, p.target, p.above, p.position, resPosition)
+		}
+	}
+}
+
+func TestRoleOrderPositions(t *testing.T) {
+	roles := []*disgord.Role{
+		{ID: 1, Position: 0},
+		{ID: 2, Position: 1},
+		{ID: 3, Position: 2},
+		{ID: 4, Position: 3},
+		{ID: 5, Position: 4},
+	}
+
+	params := []struct {
+		roleIds []disgord.Snowflake
+		order   []disgord.Snowflake
+		err     bool
+	}{
+		{roleIds: []disgord.Snowflake{2, 4}, order: []disgord.Snowflake{1, 4, 3, 2, 5}},
+		{roleIds: []disgord.Snowflake{5, 3, 2}, order: []disgord.Snowflake{1, 2, 3, 4, 5}},
+		{roleIds: []disgord.Snowflake{3, 5, 2}, order: []disgord.Snowflake{1, 2, 5, 4, 3}},
+		{roleIds: []disgord.Snowflake{2, 1}, err: true},
+		{roleIds: []disgord.Snowflake{2, 6}, err: true},
+		{roleIds: []disgord.Snowflake{2, 2}, err: true},
+	}
+
+	for _, p := range params {
+		resParams, err := getRoleOrderPositions(roles, 1, p.roleIds)
+		if (err != nil) != p.err {
+			t.Errorf("roles: %v - error Error: ex: %v, ac: %v", p.roleIds, p.err, err)
+			continue
+		}
+		if p.err {
+			continue
+		}
+
+		order := make([]disgord.Snowflake, 0, len(resParams))
+		for _, r := range resParams {
+			order = append(order, r.ID)
+		}
+		if !reflect.DeepEqual(p.order, order) {
+			t.Errorf("roles: %v - order Error: ex: %v, ac: %v", p.roleIds, p.order, order)
+		}
+	}
+}
+
+func TestRoleTags(t *testing.T) {
+	params := []struct {
+		tags     string
+		expected map[string]interface{}
+	}{
+		{tags: `{}`, expected: map[string]interface{}{"is_premium_subscriber": false, "bot_id": "", "integration_id": ""}},
+		{tags: `{"premium_subscriber": null}`, expected: map[string]interface{}{"is_premium_subscriber": true, "bot_id": "", "integration_id": ""}},
+		{tags: `{"bot_id": "5"}`, expected: map[string]interface{}{"is_premium_subscriber": false, "bot_id": "5", "integration_id": ""}},
+		{tags: `{"integration_id": "6"}`, expected: map[string]interface{}{"is_premium_subscriber": false, "bot_id": "", "integration_id": "6"}},
+	}
+
+	for _, p := range params {
+		var tags roleTags
+		if err := json.Unmarshal([]byte(p.tags), &tags); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		res := flattenRoleTags(tags)
+		if !reflect.DeepEqual(res[0], p.expected) {
+			t.Errorf("tags: %v - tags Error: ex: %v, ac: %v", p.tags, p.expected, res[0])
+		}
+	}
+}
+
+func TestMemberServerPermissions(t *testing.T) {
+	roles := []*disgord.Role{
+		{ID: 1, Permissions: 0x400},
+		{ID: 2, Permissions: 0x800},
+		{ID: 3, Permissions: 0x2000},
+		{ID: 4, Permissions: 0x8},
+	}
+
+	params := []struct {
+		roles    []disgord.Snowflake
+		isOwner  bool
+		expected []string
+	}{
+		{roles: []disgord.Snowflake{}, expected: []string{"view_channel"}},
+		{roles: []disgord.Snowflake{2, 3}, expected: []string{"manage_messages", "send_messages", "view_channel"}},
+	}
+
+	for _, p := range params {
+		bits := getMemberServerPermissions(roles, 1, &disgord.Member{Roles: p.roles}, p.isOwner)
+		if ac := getPermissionNames(bits); !reflect.DeepEqual(p.expected, ac) {
+			t.Errorf("roles: %v - permissions Error: ex: %v, ac: %v", p.roles, p.expected, ac)
+		}
+	}
+
+	all := getPermissionNames(getMemberServerPermissions(roles, 1, &disgord.Member{}, true))
+	if len(all) != len(permissions) {
+		t.Errorf("owner Error: ex: %v, ac: %v", len(permissions), len(all))
+	}
+	admin := getPermissionNames(getMemberServerPermissions(roles, 1, &disgord.Member{Roles: []disgord.Snowflake{4}}, false))
+	if len(admin) != len(permissions) {
+		t.Errorf("administrator Error: ex: %v, ac: %v", len(permissions), len(admin))
+	}
+}
+
+func TestValidateRolePermissions(t *testing.T) {
+	if _, errs := validateRolePermissions(0x400, "permissions"); len(errs) != 0 {
+		t.Errorf("allow Error: ex: %v, ac: %v", nil, errs)
+	}
+	if _, errs := validateRolePermissions(-0x400, "permissions"); len(errs) != 1 {
+		t.Errorf("deny Error: ex: %v, ac: %v", "an error pointing to channel overwrites", errs)
+	}
+}
+
+func TestMergeRolePermissions(t *testing.T) {
+	r := resourceDiscordRole()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"server_id":          "1",
+		"name":               "shared",
+		"add_permissions":    []interface{}{"send_messages", "embed_links"},
+		"remove_permissions": []interface{}{"mention_everyone", "embed_links"},
+	})
+
+	current := uint64(0x400 | 0x20000)
+	expected := uint64(0x400 | 0x800)
+	if ac := mergeRolePermissions(current, d); ac != expected {
+		t.Errorf("permissions Error: ex: %#x, ac: %#x", expected, ac)
+	}
+
+	// Permissions which already match need no update.
+	if suppress := r.Schema["permissions"].DiffSuppressFunc("permissions", "3072", "0", d); !suppress {
+		t.Errorf("diff Error: ex: %v, ac: %v", true, suppress)
+	}
+	if suppress := r.Schema["permissions"].DiffSuppressFunc("permissions", "1024", "0", d); suppress {
+		t.Errorf("diff Error: ex: %v, ac: %v", false, suppress)
+	}
+}
+
+func TestCountRoleMembers(t *testing.T) {
+	members := `[{"user": {"id": "2"}, "roles": ["5"]}, {"user": {"id": "3"}, "roles": ["5", "6"]}, {"user": {"id": "4"}, "roles": []}]`
+	c, _ := newTestContext(t, map[string][]mockResponse{
+		"GET /guilds/1/members": {{status: http.StatusOK, body: members}},
+	})
+
+	params := []struct {
+		roleId   string
+		expected int
+	}{
+		{roleId: "5", expected: 2},
+		{roleId: "6", expected: 1},
+		{roleId: "7", expected: 0},
+		{roleId: "1", expected: 3},
+	}
+
+	for _, p := range params {
+		count, err := countRoleMembers(c.Client, getId("1"), getId(p.roleId))
+		if err != nil {
+			t.Fatalf("role: %v - err: %s", p.roleId, err)
+		}
+		if count != p.expected {
+			t.Errorf("role: %v - count Error: ex: %v, ac: %v", p.roleId, p.expected, count)
+		}
+	}
+}
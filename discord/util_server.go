@@ -0,0 +1,460 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/andersfylling/disgord"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// guildExtras holds the server attributes which disgord doesn't model yet.
+type guildExtras struct {
+	SystemChannelFlags    *int             `json:"system_channel_flags,omitempty"`
+	SafetyAlertsChannelID *nullableString  `json:"safety_alerts_channel_id,omitempty"`
+	MaxMembers            *int             `json:"max_members,omitempty"`
+	MaxPresences          *int             `json:"max_presences,omitempty"`
+	Features              *[]string        `json:"features,omitempty"`
+	HubType               *int             `json:"hub_type,omitempty"`
+	AFKChannelID          *nullableString  `json:"afk_channel_id,omitempty"`
+	AFKTimeout            *int             `json:"afk_timeout,omitempty"`
+	IncidentsData         *incidentActions `json:"incidents_data,omitempty"`
+	PremiumTier           *int             `json:"premium_tier,omitempty"`
+	Emojis                *[]serverAsset   `json:"emojis,omitempty"`
+	Stickers              *[]serverAsset   `json:"stickers,omitempty"`
+	Splash                *string          `json:"splash,omitempty"`
+	OwnerID               *string          `json:"owner_id,omitempty"`
+	VerificationLevel     *int             `json:"verification_level,omitempty"`
+	MessageNotifications  *int             `json:"default_message_notifications,omitempty"`
+	ExplicitContentFilter *int             `json:"explicit_content_filter,omitempty"`
+	VanityURLCode         *string          `json:"vanity_url_code,omitempty"`
+	NSFWLevel             *int             `json:"nsfw_level,omitempty"`
+}
+
+// serverAsset is an emoji or sticker of a server, only counted against the slots of its boost tier.
+type serverAsset struct {
+	Animated bool `json:"animated,omitempty"`
+}
+
+func getGuildExtras(ctx context.Context, m interface{}, serverId disgord.Snowflake) (*guildExtras, error) {
+	var extras guildExtras
+	if err := discordRequest(ctx, m, http.MethodGet, fmt.Sprintf("/guilds/%s", serverId.String()), nil, &extras); err != nil {
+		return nil, err
+	}
+
+	return &extras, nil
+}
+
+func updateGuildExtras(ctx context.Context, m interface{}, serverId disgord.Snowflake, extras *guildExtras) error {
+	return discordRequest(ctx, m, http.MethodPatch, fmt.Sprintf("/guilds/%s", serverId.String()), extras, nil)
+}
+
+// normalizeServerRegion lowercases a region, Discord reports regions it no longer uses as deprecated, which is the same
+// as no region at all.
+func normalizeServerRegion(region string) string {
+	region = strings.ToLower(strings.TrimSpace(region))
+	if region == "deprecated" {
+		return ""
+	}
+
+	return region
+}
+
+// suppressServerRegionDiff ignores regions differing only in case, and any region once Discord doesn't report one,
+// since Discord picks the voice region by itself then.
+func suppressServerRegionDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeServerRegion(old) == normalizeServerRegion(new) || (d.Id() != "" && normalizeServerRegion(old) == "")
+}
+
+// afkTimeouts are the AFK timeouts Discord documents and offers in its client.
+// See: https://discord.com/developers/docs/resources/guild#guild-object-guild-structure
+var afkTimeouts = []int{60, 300, 900, 1800, 3600}
+
+// validateAFKTimeout only warns about whole minutes missing from the documented timeouts, as Discord may accept more
+// values than it documents.
+func validateAFKTimeout(val interface{}, key string) (warns []string, errors []error) {
+	v := val.(int)
+	if contains(afkTimeouts, v) {
+		return
+	}
+
+	if v > 0 && v%60 == 0 {
+		warns = append(warns, fmt.Sprintf("%s %d isn't one of the documented values %v, Discord may reject it", key, v, afkTimeouts))
+	} else {
+		errors = append(errors, fmt.Errorf("%s must be a whole number of minutes in seconds, one of %v, but got: %d", key, afkTimeouts, v))
+	}
+
+	return
+}
+
+// isBotOwner reports whether the bot owns the server, which some settings like the MFA level require.
+// The ID of the bot is returned along, so that callers don't fetch the bot user again.
+func isBotOwner(client *disgord.Client, server *disgord.Guild) (disgord.Snowflake, bool, error) {
+	bot, err := client.CurrentUser().Get()
+	if err != nil {
+		return 0, false, err
+	}
+
+	return bot.ID, bot.ID == server.OwnerID, nil
+}
+
+// ownerTransfer is sent to transfer the ownership of a server. Owners with two-factor authentication have to send a
+// current code of their authenticator app along.
+type ownerTransfer struct {
+	OwnerID string `json:"owner_id"`
+	Code    string `json:"code,omitempty"`
+}
+
+// transferServerOwnership makes another member the owner of the server.
+func transferServerOwnership(ctx context.Context, m interface{}, serverId disgord.Snowflake, ownerId string, mfaCode string) error {
+	err := discordRequest(ctx, m, http.MethodPatch, fmt.Sprintf("/guilds/%s", serverId.String()), &ownerTransfer{OwnerID: ownerId, Code: mfaCode}, nil)
+	if isDiscordError(err, discordErrorMFARequired) {
+		if mfaCode == "" {
+			return fmt.Errorf("the owner account has two-factor authentication enabled, set mfa_code to a current code of its authenticator app: %s", err.Error())
+		}
+		return fmt.Errorf("Discord rejected mfa_code, codes are only valid for about 30 seconds: %s", err.Error())
+	}
+
+	return err
+}
+
+// serverFeatureInvitesDisabled pauses the invites of a server, e.g. during a raid.
+const serverFeatureInvitesDisabled = "INVITES_DISABLED"
+
+// serverFeatureAnimatedIcon allows a server to have an animated GIF icon.
+const serverFeatureAnimatedIcon = "ANIMATED_ICON"
+
+// setServerFeature turns one of the features which admins may toggle themselves on or off.
+// Discord expects the complete list of features, so the others are sent unchanged.
+func setServerFeature(ctx context.Context, m interface{}, server *disgord.Guild, feature string, enabled bool) error {
+	features := make([]string, 0, len(server.Features)+1)
+	for _, f := range server.Features {
+		if f != feature {
+			features = append(features, f)
+		}
+	}
+	if enabled {
+		features = append(features, feature)
+	}
+
+	if err := updateGuildExtras(ctx, m, server.ID, &guildExtras{Features: &features}); err != nil {
+		return err
+	}
+	server.Features = features
+
+	return nil
+}
+
+// configurableServerFeatures are the features admins may toggle themselves which the features argument manages.
+// INVITES_DISABLED is toggled as well, but through invites_disabled.
+// See: https://discord.com/developers/docs/resources/guild#guild-object-mutable-guild-features
+var configurableServerFeatures = []string{"COMMUNITY", "DISCOVERABLE", "RAID_ALERTS_DISABLED"}
+
+// getConfigurableServerFeatures returns the enabled features which the features argument manages.
+func getConfigurableServerFeatures(features []string) []string {
+	configurable := make([]string, 0)
+	for _, f := range features {
+		if contains(configurableServerFeatures, f) {
+			configurable = append(configurable, f)
+		}
+	}
+
+	return configurable
+}
+
+// mergeServerFeatures replaces the configurable features with the enabled ones, the features Discord grants are kept.
+func mergeServerFeatures(current []string, enabled []string) []string {
+	features := make([]string, 0, len(current)+len(enabled))
+	for _, f := range current {
+		if !contains(configurableServerFeatures, f) {
+			features = append(features, f)
+		}
+	}
+
+	return append(features, enabled...)
+}
+
+// setServerFeatures enables exactly the given configurable features, the features Discord grants are sent unchanged.
+func setServerFeatures(ctx context.Context, m interface{}, server *disgord.Guild, enabled []string) error {
+	features := mergeServerFeatures(server.Features, enabled)
+
+	if err := updateGuildExtras(ctx, m, server.ID, &guildExtras{Features: &features}); err != nil {
+		return err
+	}
+	server.Features = features
+
+	return nil
+}
+
+// Emoji and sticker slots by boost tier. The emoji slots apply to static and animated emojis each.
+var (
+	emojiLimits   = []int{50, 100, 150, 250}
+	stickerLimits = []int{5, 15, 30, 60}
+)
+
+// getServerAssetLimits returns the emoji and sticker slots of a server. MORE_EMOJI and MORE_STICKERS,
+// which Discord grants e.g. to partnered servers, raise them to at least 200 emojis and 60 stickers.
+func getServerAssetLimits(premiumTier int, features []string) (int, int) {
+	if premiumTier < 0 || premiumTier >= len(emojiLimits) {
+		premiumTier = 0
+	}
+	emojis, stickers := emojiLimits[premiumTier], stickerLimits[premiumTier]
+
+	if contains(features, "MORE_EMOJI") && emojis < 200 {
+		emojis = 200
+	}
+	if contains(features, "MORE_STICKERS") && stickers < 60 {
+		stickers = 60
+	}
+
+	return emojis, stickers
+}
+
+// getServerVanityURLCode returns the vanity invite code of a server, if it has the VANITY_URL feature and a code is set.
+func getServerVanityURLCode(extras *guildExtras) (string, bool) {
+	if extras.Features == nil || !contains(*extras.Features, "VANITY_URL") {
+		return "", false
+	}
+	if extras.VanityURLCode == nil || *extras.VanityURLCode == "" {
+		return "", false
+	}
+
+	return *extras.VanityURLCode, true
+}
+
+// See: https://discord.com/developers/docs/resources/guild#guild-object-system-channel-flags
+var systemChannelFlags = map[string]int{
+	"suppress_join_notifications":           1 << 0,
+	"suppress_premium_subscriptions":        1 << 1,
+	"suppress_guild_reminder_notifications": 1 << 2,
+	"suppress_join_notification_replies":    1 << 3,
+}
+
+func getSystemChannelFlags(names []interface{}) int {
+	flags := 0
+	for _, name := range names {
+		flags |= systemChannelFlags[name.(string)]
+	}
+
+	return flags
+}
+
+// setSystemChannelFlag sets or clears a single flag, leaving the others as they are.
+func setSystemChannelFlag(flags int, name string, enabled bool) int {
+	if enabled {
+		return flags | systemChannelFlags[name]
+	}
+
+	return flags &^ systemChannelFlags[name]
+}
+
+// warnSystemJoinMessages warns when join messages are turned on while the server has no system channel, Discord has
+// nowhere to send them then.
+func warnSystemJoinMessages(channelId disgord.Snowflake, flags int) diag.Diagnostics {
+	if !channelId.IsZero() || flags&systemChannelFlags["suppress_join_notifications"] != 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Join messages are enabled without a system channel",
+		Detail:   "Set system_channel_id, or add suppress_join_notifications to the system channel flags to turn join messages off.",
+	}}
+}
+
+func getSystemChannelFlagNames(flags int) []string {
+	names := make([]string, 0, len(systemChannelFlags))
+	for name, bit := range systemChannelFlags {
+		if flags&bit != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// serverChannelKey identifies a channel of the channel block of a managed server, renaming a channel replaces it.
+func serverChannelKey(c map[string]interface{}) string {
+	return c["type"].(string) + "/" + c["name"].(string)
+}
+
+// reconcileServerChannels makes sure the channels of the channel block exist in the server. Only the channels created
+// through the block are tracked, so the rest of the server is left alone.
+func reconcileServerChannels(ctx context.Context, m interface{}, serverId disgord.Snowflake, d *schema.ResourceData) diag.Diagnostics {
+	client := m.(*Context).Client
+	o, n := d.GetChange("channel")
+
+	tracked := make(map[string]map[string]interface{})
+	for _, v := range o.([]interface{}) {
+		c := v.(map[string]interface{})
+		if c["id"].(string) != "" {
+			tracked[serverChannelKey(c)] = c
+		}
+	}
+
+	wanted := make([]map[string]interface{}, 0, len(n.([]interface{})))
+	wantedKeys := make(map[string]bool)
+	deleted := make(map[string]bool)
+	ids := make(map[string]string)
+
+	// The channels are stored in the configured order, so the list doesn't diff. When reconciling fails, the channels
+	// which weren't reconciled yet keep their previous state, so the next apply picks up where this one stopped.
+	setChannels := func() {
+		result := make([]map[string]interface{}, 0, len(tracked)+len(wanted))
+		for _, c := range wanted {
+			if id, ok := ids[serverChannelKey(c)]; ok {
+				result = append(result, map[string]interface{}{
+					"name":     c["name"],
+					"type":     c["type"],
+					"category": c["category"],
+					"id":       id,
+				})
+			} else if old, ok := tracked[serverChannelKey(c)]; ok {
+				result = append(result, old)
+			}
+		}
+		keys := make([]string, 0, len(tracked))
+		for key := range tracked {
+			if !wantedKeys[key] && !deleted[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result = append(result, tracked[key])
+		}
+
+		d.Set("channel", result)
+	}
+	fail := func(format string, a ...interface{}) diag.Diagnostics {
+		setChannels()
+		return diag.Errorf(format, a...)
+	}
+
+	categories := make(map[string]bool)
+	for _, v := range n.([]interface{}) {
+		c := v.(map[string]interface{})
+		if wantedKeys[serverChannelKey(c)] {
+			return fail("channel %s is declared more than once", serverChannelKey(c))
+		}
+		wantedKeys[serverChannelKey(c)] = true
+		if c["type"].(string) == "category" {
+			if c["category"].(string) != "" {
+				return fail("category %s cannot be a child of another category", c["name"].(string))
+			}
+			categories[c["name"].(string)] = true
+		}
+		wanted = append(wanted, c)
+	}
+	for _, c := range wanted {
+		if category := c["category"].(string); category != "" && !categories[category] {
+			return fail("category %s of channel %s must be declared as a channel of type category", category, c["name"].(string))
+		}
+	}
+
+	for key, c := range tracked {
+		if wantedKeys[key] {
+			continue
+		}
+		if _, err := client.Channel(getId(c["id"].(string))).Delete(); err != nil && !isDiscordError(err, discordErrorUnknownChannel) {
+			return fail("Failed to delete channel %s: %s", key, err.Error())
+		}
+		deleted[key] = true
+	}
+
+	// Categories go first, so the other channels can be created in them.
+	ordered := make([]map[string]interface{}, 0, len(wanted))
+	for _, category := range []bool{true, false} {
+		for _, c := range wanted {
+			if (c["type"].(string) == "category") == category {
+				ordered = append(ordered, c)
+			}
+		}
+	}
+
+	categoryIds := make(map[string]disgord.Snowflake)
+	for _, c := range ordered {
+		var parentId disgord.Snowflake
+		if category := c["category"].(string); category != "" {
+			parentId = categoryIds[category]
+		}
+
+		old, exists := tracked[serverChannelKey(c)]
+		var id string
+		switch {
+		case !exists:
+			channelType, _ := getDiscordChannelType(c["type"].(string))
+			channel, err := client.Guild(serverId).CreateChannel(c["name"].(string), &disgord.CreateGuildChannel{
+				Type:     channelType,
+				ParentID: parentId,
+			})
+			if err != nil {
+				return fail("Failed to create channel %s: %s", serverChannelKey(c), err.Error())
+			}
+			id = channel.ID.String()
+		case old["category"] != c["category"]:
+			id = old["id"].(string)
+			move := nullableString("")
+			if !parentId.IsZero() {
+				move = nullableString(parentId.String())
+			}
+			if err := updateChannelExtras(ctx, m, getId(id), &channelExtras{ParentID: &move}); err != nil {
+				return fail("Failed to move channel %s: %s", serverChannelKey(c), err.Error())
+			}
+		default:
+			id = old["id"].(string)
+		}
+
+		if c["type"].(string) == "category" {
+			categoryIds[c["name"].(string)] = getId(id)
+		}
+		ids[serverChannelKey(c)] = id
+	}
+
+	setChannels()
+
+	return nil
+}
+
+// readServerChannels refreshes the channels of the channel block, channels deleted outside of Terraform are dropped.
+func readServerChannels(client *disgord.Client, d *schema.ResourceData) diag.Diagnostics {
+	channels := make([]*disgord.Channel, 0)
+	for _, v := range d.Get("channel").([]interface{}) {
+		c := v.(map[string]interface{})
+		channel, err := client.Channel(getId(c["id"].(string))).Get()
+		if err != nil {
+			if isDiscordError(err, discordErrorUnknownChannel) {
+				continue
+			}
+			return diag.Errorf("Failed to fetch channel %s: %s", serverChannelKey(c), err.Error())
+		}
+		channels = append(channels, channel)
+	}
+
+	categoryNames := make(map[disgord.Snowflake]string)
+	for _, channel := range channels {
+		if channelType, _ := getTextChannelType(channel.Type); channelType == "category" {
+			categoryNames[channel.ID] = channel.Name
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(channels))
+	for _, channel := range channels {
+		channelType, _ := getTextChannelType(channel.Type)
+		result = append(result, map[string]interface{}{
+			"name":     channel.Name,
+			"type":     channelType,
+			"category": categoryNames[channel.ParentID],
+			"id":       channel.ID.String(),
+		})
+	}
+
+	d.Set("channel", result)
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andersfylling/disgord"
+)
+
+func TestSystemChannelFlags(t *testing.T) {
+	params := []struct {
+		flags int
+		names []string
+	}{
+		{flags: 0, names: []string{}},
+		{flags: 1, names: []string{"suppress_join_notifications"}},
+		{flags: 2, names: []string{"suppress_premium_subscriptions"}},
+		{flags: 4, names: []string{"suppress_guild_reminder_notifications"}},
+		{flags: 8, names: []string{"suppress_join_notification_replies"}},
+		{flags: 15, names: []string{
+			"suppress_guild_reminder_notifications",
+			"suppress_join_notification_replies",
+			"suppress_join_notifications",
+			"suppress_premium_subscriptions",
+		}},
+	}
+
+	for _, p := range params {
+		resNames := getSystemChannelFlagNames(p.flags)
+		if !reflect.DeepEqual(p.names, resNames) {
+			t.Errorf("flags: %v - names Error: ex: %v, ac: %v", p.flags, p.names, resNames)
+		}
+
+		names := make([]interface{}, 0, len(p.names))
+		for _, n := range p.names {
+			names = append(names, n)
+		}
+		if resFlags := getSystemChannelFlags(names); p.flags != resFlags {
+			t.Errorf("flags: %v - flags Error: ex: %v, ac: %v", p.flags, p.flags, resFlags)
+		}
+	}
+}
+
+func TestSetSystemChannelFlag(t *testing.T) {
+	params := []struct {
+		flags    int
+		enabled  bool
+		expected int
+	}{
+		{flags: 0, enabled: true, expected: 2},
+		{flags: 13, enabled: true, expected: 15},
+		{flags: 15, enabled: false, expected: 13},
+		{flags: 2, enabled: false, expected: 0},
+		{flags: 1, enabled: false, expected: 1},
+	}
+
+	for _, p := range params {
+		if ac := setSystemChannelFlag(p.flags, "suppress_premium_subscriptions", p.enabled); ac != p.expected {
+			t.Errorf("flags: %v, enabled: %v - flags Error: ex: %v, ac: %v", p.flags, p.enabled, p.expected, ac)
+		}
+	}
+}
+
+func TestWarnSystemJoinMessages(t *testing.T) {
+	params := []struct {
+		channelId disgord.Snowflake
+		flags     int
+		warn      bool
+	}{
+		{channelId: 0, flags: 0, warn: true},
+		{channelId: 0, flags: 2, warn: true},
+		{channelId: 0, flags: 1, warn: false},
+		{channelId: 5, flags: 0, warn: false},
+	}
+
+	for _, p := range params {
+		diags := warnSystemJoinMessages(p.channelId, p.flags)
+		if (len(diags) > 0) != p.warn || diags.HasError() {
+			t.Errorf("channel: %v, flags: %v - warning Error: ex: %v, ac: %v", p.channelId, p.flags, p.warn, diags)
+		}
+	}
+}
+
+func TestValidateAFKTimeout(t *testing.T) {
+	params := []struct {
+		timeout int
+		warns   int
+		errors  int
+	}{
+		{timeout: 300, warns: 0, errors: 0},
+		{timeout: 3600, warns: 0, errors: 0},
+		{timeout: 7200, warns: 1, errors: 0},
+		{timeout: 90, warns: 0, errors: 1},
+		{timeout: 0, warns: 0, errors: 1},
+	}
+
+	for _, p := range params {
+		warns, errs := validateAFKTimeout(p.timeout, "afk_timeout")
+		if len(warns) != p.warns {
+			t.Errorf("timeout: %v - warns Error: ex: %v, ac: %v", p.timeout, p.warns, warns)
+		}
+		if len(errs) != p.errors {
+			t.Errorf("timeout: %v - errors Error: ex: %v, ac: %v", p.timeout, p.errors, errs)
+		}
+	}
+}
+
+func TestServerAssetLimits(t *testing.T) {
+	params := []struct {
+		premiumTier int
+		features    []string
+		emojis      int
+		stickers    int
+	}{
+		{premiumTier: 0, features: []string{}, emojis: 50, stickers: 5},
+		{premiumTier: 1, features: []string{}, emojis: 100, stickers: 15},
+		{premiumTier: 2, features: []string{}, emojis: 150, stickers: 30},
+		{premiumTier: 3, features: []string{}, emojis: 250, stickers: 60},
+		{premiumTier: 1, features: []string{"MORE_EMOJI", "MORE_STICKERS"}, emojis: 200, stickers: 60},
+		{premiumTier: 3, features: []string{"MORE_EMOJI"}, emojis: 250, stickers: 60},
+	}
+
+	for _, p := range params {
+		emojis, stickers := getServerAssetLimits(p.premiumTier, p.features)
+		if emojis != p.emojis {
+			t.Errorf("tier: %v - emojis Error: ex: %v, ac: %v", p.premiumTier, p.emojis, emojis)
+		}
+		if stickers != p.stickers {
+			t.Errorf("tier: %v - stickers Error: ex: %v, ac: %v", p.premiumTier, p.stickers, stickers)
+		}
+	}
+}
+
+func TestServerVanityURLCode(t *testing.T) {
+	code, empty := "terraform", ""
+	params := []struct {
+		features []string
+		code     *string
+		expected string
+		ok       bool
+	}{
+		{features: []string{"VANITY_URL"}, code: &code, expected: "terraform", ok: true},
+		{features: []string{"VANITY_URL"}, code: &empty, expected: "", ok: false},
+		{features: []string{"VANITY_URL"}, code: nil, expected: "", ok: false},
+		{features: []string{"COMMUNITY"}, code: &code, expected: "", ok: false},
+		{features: nil, code: &code, expected: "", ok: false},
+	}
+
+	for _, p := range params {
+		extras := &guildExtras{VanityURLCode: p.code}
+		if p.features != nil {
+			extras.Features = &p.features
+		}
+
+		actual, ok := getServerVanityURLCode(extras)
+		if actual != p.expected || ok != p.ok {
+			t.Errorf("features: %v - code Error: ex: %v %v, ac: %v %v", p.features, p.expected, p.ok, actual, ok)
+		}
+	}
+}
+
+func TestTransferServerOwnershipMFA(t *testing.T) {
+	c, transport := newTestContext(t, map[string][]mockResponse{
+		"PATCH /guilds/1": {
+			{status: http.StatusForbidden, body: `{"code": 60003, "message": "Two factor is required for this operation"}`},
+			{status: http.StatusOK, body: `{"id": "1", "owner_id": "5"}`},
+		},
+	})
+
+	err := transferServerOwnership(context.Background(), c, 1, "5", "")
+	if err == nil || !strings.Contains(err.Error(), "set mfa_code") {
+		t.Errorf("without code Error: ex: %v, ac: %v", "set mfa_code", err)
+	}
+
+	if err := transferServerOwnership(context.Background(), c, 1, "5", "123456"); err != nil {
+		t.Fatalf("with code Error: ex: %v, ac: %v", nil, err)
+	}
+	if ac := transport.bodies[len(transport.bodies)-1]; !strings.Contains(ac, `"code":"123456"`) {
+		t.Errorf("payload Error: ex: %v, ac: %v", `"code":"123456"`, ac)
+	}
+}